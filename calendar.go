@@ -9,6 +9,7 @@ import (
 	"unicode"
 
 	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/driver/desktop"
 	"fyne.io/fyne/v2/lang"
 	"fyne.io/fyne/v2/layout"
 	"fyne.io/fyne/v2/theme"
@@ -27,12 +28,25 @@ var minCellContent = widget.NewLabel("22")
 
 var weekStart time.Weekday
 
+// calendarView is the grid displayed by a Calendar, tapping the month label goes from days to months to years.
+type calendarView int
+
+const (
+	viewDays calendarView = iota
+	viewMonths
+	viewYears // decade
+)
+
 func SetGlobalWeekStart(ws time.Weekday) {
 	weekStart = ws
 }
 
 // Calendar creates a new date time picker which returns a time object
 //
+// The month label switches to a grid of the months, then of the years of the decade.
+// Once tapped, the calendar has the keyboard focus: PageUp/PageDown display the previous/next month,
+// Ctrl+PageUp/Ctrl+PageDown the previous/next year, and Escape returns to the days.
+//
 // Since: 2.6
 type Calendar struct {
 	widget.BaseWidget
@@ -40,16 +54,28 @@ type Calendar struct {
 
 	monthPrevious *widget.Button
 	monthNext     *widget.Button
-	monthLabel    *widget.Label
+	monthLabel    *widget.Button
 
 	dates *fyne.Container
+	view  calendarView
 
 	SelectedDate time.Time
 	//Selectable   bool
 
 	WeekStart time.Weekday
 
+	// selectable dates (no bound if zero), other days are greyed out (see SetDisplayedDate to refresh the calendar)
+	MinDate      time.Time
+	MaxDate      time.Time
+	DisabledDate func(time.Time) bool `json:"-"` // e.g. weekends and public holidays
+
 	OnChanged func(time.Time) `json:"-"`
+
+	// hooks of DateRangeCalendar
+	dayImportance func(time.Time) (widget.Importance, bool)
+	onDayTapped   func(time.Time)
+	onDayHovered  func(time.Time)
+	onDisplayed   func(time.Time)
 }
 
 // NewCalendar creates a calendar instance
@@ -76,12 +102,24 @@ func (c *Calendar) SetDisplayedDate(date time.Time) {
 	// Dates are 'normalised', forcing date to start from the start of the month ensures move from March to February
 	c.displayedDate = time.Date(date.Year(), date.Month(), 1, 0, 0, 0, 0, time.Local)
 
-	if c.monthLabel != nil {
-		c.monthLabel.SetText(c.monthYear())
-		c.dates.Objects = c.calendarObjects()
+	c.refreshGrid()
+	if c.onDisplayed != nil {
+		c.onDisplayed(c.displayedDate)
 	}
 }
 
+// Selectable returns wether a date is between MinDate and MaxDate, and not disabled (see DisabledDate).
+func (c *Calendar) Selectable(date time.Time) bool {
+	day := dateOnly(date)
+	if !c.MinDate.IsZero() && day.Before(dateOnly(c.MinDate)) {
+		return false
+	}
+	if !c.MaxDate.IsZero() && day.After(dateOnly(c.MaxDate)) {
+		return false
+	}
+	return c.DisabledDate == nil || !c.DisabledDate(date)
+}
+
 // SetSelectedDate sets the currently selected date
 //
 // Date selection works only if .Selectable = true
@@ -102,22 +140,31 @@ func (c *Calendar) CreateRenderer() fyne.WidgetRenderer {
 	c.WeekStart = weekStart
 
 	c.monthPrevious = widget.NewButtonWithIcon("", theme.NavigateBackIcon(), func() {
-		c.SetDisplayedDate(c.displayedDate.AddDate(0, -1, 0))
+		c.focus()
+		c.navigate(-1)
 	})
 	c.monthPrevious.Importance = widget.LowImportance
 
 	c.monthNext = widget.NewButtonWithIcon("", theme.NavigateNextIcon(), func() {
-		c.SetDisplayedDate(c.displayedDate.AddDate(0, 1, 0))
+		c.focus()
+		c.navigate(1)
 	})
 	c.monthNext.Importance = widget.LowImportance
 
-	c.monthLabel = widget.NewLabel(c.monthYear())
+	c.monthLabel = &widget.Button{Importance: widget.LowImportance, OnTapped: func() {
+		c.focus()
+		if c.view < viewYears {
+			c.view++
+			c.refreshGrid()
+		}
+	}}
 
 	nav := &fyne.Container{Layout: layout.NewBorderLayout(nil, nil, c.monthPrevious, c.monthNext),
 		Objects: []fyne.CanvasObject{c.monthPrevious, c.monthNext,
 			&fyne.Container{Layout: layout.NewCenterLayout(), Objects: []fyne.CanvasObject{c.monthLabel}}}}
 
-	c.dates = &fyne.Container{Layout: newCalendarLayout(), Objects: c.calendarObjects()}
+	c.dates = &fyne.Container{}
+	c.refreshGrid()
 
 	dateContainer := &fyne.Container{Layout: layout.NewBorderLayout(nav, nil, nil, nil),
 		Objects: []fyne.CanvasObject{nav, c.dates}}
@@ -125,7 +172,144 @@ func (c *Calendar) CreateRenderer() fyne.WidgetRenderer {
 	return widget.NewSimpleRenderer(dateContainer)
 }
 
+// TypedKey displays the previous/next month with PageUp/PageDown, Escape returns to the days.
+func (c *Calendar) TypedKey(k *fyne.KeyEvent) {
+	switch k.Name {
+	case fyne.KeyPageUp:
+		c.moveDisplayed(0, -1)
+	case fyne.KeyPageDown:
+		c.moveDisplayed(0, 1)
+	case fyne.KeyEscape:
+		if c.view != viewDays {
+			c.view = viewDays
+			c.refreshGrid()
+		}
+	}
+}
+
+// TypedShortcut displays the previous/next year with Ctrl+PageUp/Ctrl+PageDown.
+func (c *Calendar) TypedShortcut(s fyne.Shortcut) {
+	cs, ok := s.(*desktop.CustomShortcut)
+	if !ok || cs.Modifier != fyne.KeyModifierControl && cs.Modifier != fyne.KeyModifierShortcutDefault {
+		return
+	}
+	switch cs.KeyName {
+	case fyne.KeyPageUp:
+		c.moveDisplayed(-1, 0)
+	case fyne.KeyPageDown:
+		c.moveDisplayed(1, 0)
+	}
+}
+
+func (c *Calendar) FocusGained()     {}
+func (c *Calendar) FocusLost()       {}
+func (c *Calendar) TypedRune(_ rune) {}
+
+// forwardKey handles the navigation keys (see TypedKey) typed in the entry of a popup showing the calendar,
+// and returns true if k is one of them.
+func (c *Calendar) forwardKey(popup *widget.PopUp, k *fyne.KeyEvent) bool {
+	if popup == nil || !popup.Visible() || k.Name != fyne.KeyPageUp && k.Name != fyne.KeyPageDown {
+		return false
+	}
+	c.TypedKey(k)
+	return true
+}
+
+// forwardShortcut is forwardKey for the shortcuts of TypedShortcut.
+func (c *Calendar) forwardShortcut(popup *widget.PopUp, s fyne.Shortcut) bool {
+	cs, ok := s.(*desktop.CustomShortcut)
+	if popup == nil || !popup.Visible() || !ok || cs.KeyName != fyne.KeyPageUp && cs.KeyName != fyne.KeyPageDown {
+		return false
+	}
+	c.TypedShortcut(s)
+	return true
+}
+
+// focus gives the keyboard focus to the calendar (see TypedKey).
+func (c *Calendar) focus() {
+	if cnv := fyne.CurrentApp().Driver().CanvasForObject(c); cnv != nil {
+		cnv.Focus(c)
+	}
+}
+
+// refreshGrid updates the month label, the grid and the arrows of the current view.
+func (c *Calendar) refreshGrid() {
+	if c.monthLabel == nil {
+		return
+	}
+	c.monthLabel.SetText(c.title())
+	c.dates.Layout = newCalendarLayout(c.columns())
+	c.dates.Objects = c.calendarObjects()
+	c.dates.Refresh()
+	c.updateNavigation()
+}
+
+// navigate displays the previous (dir < 0) or next month, year or decade, depending on the view.
+func (c *Calendar) navigate(dir int) {
+	switch c.view {
+	case viewMonths:
+		c.moveDisplayed(dir, 0)
+	case viewYears:
+		c.moveDisplayed(10*dir, 0)
+	default:
+		c.moveDisplayed(0, dir)
+	}
+}
+
+// moveDisplayed moves the displayed month, within the months of MinDate and MaxDate.
+func (c *Calendar) moveDisplayed(years, months int) {
+	n := monthIndex(c.displayedDate) + 12*years + months
+	if !c.MinDate.IsZero() && n < monthIndex(c.MinDate) {
+		n = monthIndex(c.MinDate)
+	}
+	if !c.MaxDate.IsZero() && n > monthIndex(c.MaxDate) {
+		n = monthIndex(c.MaxDate)
+	}
+	c.SetDisplayedDate(time.Date(n/12, time.Month(n%12+1), 1, 0, 0, 0, 0, time.Local))
+}
+
+// period returns the first and last months displayed by the view (see monthIndex).
+func (c *Calendar) period() (first, last int) {
+	n := monthIndex(c.displayedDate)
+	switch c.view {
+	case viewMonths:
+		return n - n%12, n - n%12 + 11
+	case viewYears:
+		decade := c.displayedDate.Year() - c.displayedDate.Year()%10
+		return 12 * decade, 12*(decade+10) - 1
+	}
+	return n, n
+}
+
+func (c *Calendar) columns() int {
+	switch c.view {
+	case viewMonths:
+		return 3
+	case viewYears:
+		return 4
+	}
+	return daysPerWeek
+}
+
+func (c *Calendar) title() string {
+	switch c.view {
+	case viewMonths:
+		return fmt.Sprintf("%04d", c.displayedDate.Year())
+	case viewYears:
+		decade := c.displayedDate.Year() - c.displayedDate.Year()%10
+		return fmt.Sprintf("%04d – %04d", decade, decade+9)
+	}
+	return c.monthYear()
+}
+
 func (c *Calendar) calendarObjects() []fyne.CanvasObject {
+	switch c.view {
+	case viewMonths:
+		return c.monthsOfYear()
+	case viewYears:
+		return c.yearsOfDecade()
+	}
+
 	offset := int(c.WeekStart)
 
 	var columnHeadings []fyne.CanvasObject
@@ -137,9 +321,16 @@ func (c *Calendar) calendarObjects() []fyne.CanvasObject {
 	return append(columnHeadings, c.daysOfMonth()...)
 }
 
+// updateNavigation disables the arrows past MinDate and MaxDate.
+func (c *Calendar) updateNavigation() {
+	first, last := c.period()
+	setEnabled(c.monthPrevious, c.MinDate.IsZero() || monthIndex(c.MinDate) < first)
+	setEnabled(c.monthNext, c.MaxDate.IsZero() || monthIndex(c.MaxDate) > last)
+}
+
 func (c *Calendar) dateForButton(dayNum int) time.Time {
-	oldName, off := c.displayedDate.Zone()
-	return time.Date(c.displayedDate.Year(), c.displayedDate.Month(), dayNum, c.displayedDate.Hour(), c.displayedDate.Minute(), 0, 0, time.FixedZone(oldName, off)).In(c.displayedDate.Location())
+	// midnight of the day, the displayed date may carry the time of day it was created from
+	return time.Date(c.displayedDate.Year(), c.displayedDate.Month(), dayNum, 0, 0, 0, 0, c.displayedDate.Location())
 }
 
 func (c *Calendar) updateSelection() {
@@ -149,27 +340,30 @@ func (c *Calendar) updateSelection() {
 
 	defer c.dates.Refresh()
 
-	if c.SelectedDate.IsZero() || c.SelectedDate.Month() != c.displayedDate.Month() || c.SelectedDate.Year() != c.displayedDate.Year() {
-		for i := 0; i < len(c.dates.Objects); i++ {
-			if b, ok := c.dates.Objects[i].(*widget.Button); ok {
-				b.Importance = widget.LowImportance
+	for i := 0; i < len(c.dates.Objects); i++ {
+		if b, ok := c.dates.Objects[i].(*calendarDay); ok {
+			if imp := c.importance(b.date); imp != b.Importance {
+				b.Importance = imp
 				b.Refresh()
 			}
 		}
-		return
 	}
+}
 
-	for i := 0; i < len(c.dates.Objects); i++ {
-		if b, ok := c.dates.Objects[i].(*widget.Button); ok {
-			dayNum, _ := strconv.Atoi(b.Text)
-			if dayNum == c.SelectedDate.Day() {
-				b.Importance = widget.HighImportance
-			} else {
-				b.Importance = widget.LowImportance
-			}
-			b.Refresh()
+// importance returns the importance of a day button: selected, today, or the one given by dayImportance.
+func (c *Calendar) importance(date time.Time) widget.Importance {
+	if c.dayImportance != nil {
+		if imp, ok := c.dayImportance(date); ok {
+			return imp
 		}
 	}
+	if !c.SelectedDate.IsZero() && dateOnly(c.SelectedDate).Equal(dateOnly(date)) {
+		return widget.HighImportance
+	}
+	if dateOnly(time.Now()).Equal(dateOnly(date)) {
+		return widget.MediumImportance
+	}
+	return widget.LowImportance
 }
 
 func (c *Calendar) daysOfMonth() []fyne.CanvasObject {
@@ -187,12 +381,14 @@ func (c *Calendar) daysOfMonth() []fyne.CanvasObject {
 		buttons = append(buttons, layout.NewSpacer())
 	}
 
-	startYear := start.Year()
-	nowY, nowYD := time.Now().Year(), time.Now().YearDay()
-
 	for d := start; d.Month() == start.Month(); d = d.AddDate(0, 0, 1) {
 		dayNum := d.Day()
-		b := widget.NewButton(strconv.Itoa(dayNum), func() {
+		b := newCalendarDay(c, c.dateForButton(dayNum), func() {
+			if c.onDayTapped != nil {
+				c.onDayTapped(c.dateForButton(dayNum))
+				return
+			}
+
 			oldSel := c.SelectedDate
 			c.SelectedDate = c.dateForButton(dayNum)
 
@@ -207,12 +403,9 @@ func (c *Calendar) daysOfMonth() []fyne.CanvasObject {
 			}
 		})
 
-		if !c.SelectedDate.IsZero() && c.SelectedDate.Year() == c.displayedDate.Year() && c.SelectedDate.Month() == c.displayedDate.Month() && c.SelectedDate.Day() == dayNum {
-			b.Importance = widget.HighImportance
-		} else if nowY == startYear && nowYD == d.YearDay() {
-			b.Importance = widget.MediumImportance
-		} else {
-			b.Importance = widget.LowImportance
+		b.Importance = c.importance(d)
+		if !c.Selectable(d) {
+			b.Disable()
 		}
 
 		buttons = append(buttons, b)
@@ -221,18 +414,98 @@ func (c *Calendar) daysOfMonth() []fyne.CanvasObject {
 	return buttons
 }
 
+// monthsOfYear returns the month buttons of the months view, a tapped month is displayed in the days view.
+func (c *Calendar) monthsOfYear() []fyne.CanvasObject {
+	var buttons []fyne.CanvasObject
+	for m := time.January; m <= time.December; m++ {
+		from := time.Date(c.displayedDate.Year(), m, 1, 0, 0, 0, 0, time.Local)
+		months := int(m - c.displayedDate.Month())
+		b := &widget.Button{Text: shortMonthName(m.String()), Importance: c.periodImportance(from, from.AddDate(0, 1, -1)), OnTapped: func() {
+			c.view = viewDays
+			c.moveDisplayed(0, months)
+		}}
+		if !c.periodSelectable(from, from.AddDate(0, 1, -1)) {
+			b.Disable()
+		}
+		buttons = append(buttons, b)
+	}
+	return buttons
+}
+
+// yearsOfDecade returns the year buttons of the years view (the decade, and the years before and after it),
+// a tapped year is displayed in the months view.
+func (c *Calendar) yearsOfDecade() []fyne.CanvasObject {
+	decade := c.displayedDate.Year() - c.displayedDate.Year()%10
+	var buttons []fyne.CanvasObject
+	for y := decade - 1; y <= decade+10; y++ {
+		from := time.Date(y, time.January, 1, 0, 0, 0, 0, time.Local)
+		years := y - c.displayedDate.Year()
+		b := &widget.Button{Text: strconv.Itoa(y), Importance: c.periodImportance(from, from.AddDate(1, 0, -1)), OnTapped: func() {
+			c.view = viewMonths
+			c.moveDisplayed(years, 0)
+		}}
+		if !c.periodSelectable(from, from.AddDate(1, 0, -1)) {
+			b.Disable()
+		}
+		buttons = append(buttons, b)
+	}
+	return buttons
+}
+
+// periodImportance returns the importance of a month or year button: containing the selected date, or today.
+func (c *Calendar) periodImportance(from, to time.Time) widget.Importance {
+	in := func(t time.Time) bool { return !dateOnly(t).Before(dateOnly(from)) && !dateOnly(t).After(dateOnly(to)) }
+	if !c.SelectedDate.IsZero() && in(c.SelectedDate) {
+		return widget.HighImportance
+	}
+	if in(time.Now()) {
+		return widget.MediumImportance
+	}
+	return widget.LowImportance
+}
+
+// periodSelectable returns wether a month or year intersects [MinDate, MaxDate].
+func (c *Calendar) periodSelectable(from, to time.Time) bool {
+	return (c.MinDate.IsZero() || !dateOnly(to).Before(dateOnly(c.MinDate))) &&
+		(c.MaxDate.IsZero() || !dateOnly(from).After(dateOnly(c.MaxDate)))
+}
+
 func (c *Calendar) monthYear() string {
 	return monthName(c.displayedDate.Format("January")) + fmt.Sprintf(" %04d", c.displayedDate.Year())
 }
 
 //
 
+// calendarDay is a day button, reporting the hovered day (see DateRangeCalendar).
+type calendarDay struct {
+	widget.Button
+	cal  *Calendar
+	date time.Time
+}
+
+func newCalendarDay(c *Calendar, date time.Time, tapped func()) *calendarDay {
+	b := &calendarDay{cal: c, date: date}
+	b.Text = strconv.Itoa(date.Day())
+	b.OnTapped = tapped
+	b.ExtendBaseWidget(b)
+	return b
+}
+
+func (b *calendarDay) MouseIn(me *desktop.MouseEvent) {
+	b.Button.MouseIn(me)
+	if b.cal.onDayHovered != nil && !b.Disabled() {
+		b.cal.onDayHovered(b.date)
+	}
+}
+
+// calendarLayout is the grid of the days (with the week days headings), months or years of a Calendar.
 type calendarLayout struct {
+	cols     int
 	cellSize fyne.Size
 }
 
-func newCalendarLayout() fyne.Layout {
-	return &calendarLayout{}
+func newCalendarLayout(cols int) fyne.Layout {
+	return &calendarLayout{cols: cols}
 }
 
 // Layout is called to pack all child objects into a specified size.
@@ -245,13 +518,13 @@ func (g *calendarLayout) Layout(objects []fyne.CanvasObject, size fyne.Size) {
 			continue
 		}
 
-		if day%daysPerWeek == 0 && i >= daysPerWeek {
+		if day%g.cols == 0 && i >= g.cols {
 			weeks++
 		}
 		day++
 	}
 
-	g.cellSize = fyne.NewSize(size.Width/float32(daysPerWeek),
+	g.cellSize = fyne.NewSize(size.Width/float32(g.cols),
 		size.Height/float32(weeks))
 	row, col := 0, 0
 	i := 0
@@ -265,7 +538,7 @@ func (g *calendarLayout) Layout(objects []fyne.CanvasObject, size fyne.Size) {
 		child.Move(lead)
 		child.Resize(fyne.NewSize(trail.X, trail.Y).Subtract(lead))
 
-		if (i+1)%daysPerWeek == 0 {
+		if (i+1)%g.cols == 0 {
 			row++
 			col = 0
 		} else {
@@ -275,7 +548,7 @@ func (g *calendarLayout) Layout(objects []fyne.CanvasObject, size fyne.Size) {
 	}
 }
 
-// MinSize sets the minimum size for the calendar
+// MinSize sets the minimum size for the calendar, the size of the days grid whatever the view
 func (g *calendarLayout) MinSize(_ []fyne.CanvasObject) fyne.Size {
 	pad := theme.Padding()
 	largestMin := minCellContent.MinSize()
@@ -300,6 +573,16 @@ func (g *calendarLayout) getTrailing(row, col int) fyne.Position {
 
 //
 
+// dateOnly returns the day of t, for day comparisons.
+func dateOnly(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// monthIndex returns the month of t as a number of months (12*year + month-1), for month comparisons.
+func monthIndex(t time.Time) int {
+	return 12*t.Year() + int(t.Month()) - 1
+}
+
 func shortDayName(in string) string {
 	lower := strings.ToLower(in)
 	key := lower + ".short"
@@ -307,6 +590,11 @@ func shortDayName(in string) string {
 	return strings.ToUpper(lang.X(key, long[:3]))
 }
 
+func shortMonthName(in string) string {
+	long := []rune(monthName(in))
+	return lang.X(strings.ToLower(in)+".short", string(long[:3]))
+}
+
 func monthName(in string) string {
 	r := []rune(lang.X(strings.ToLower(in), in))
 	r[0] = unicode.ToUpper(r[0])
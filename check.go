@@ -2,6 +2,7 @@ package wx
 
 import (
 	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/data/binding"
 	"fyne.io/fyne/v2/driver/desktop"
 	"fyne.io/fyne/v2/widget"
 )
@@ -12,25 +13,66 @@ type Check struct {
 	ToolTipable
 
 	// custom callbacks
+	OnChanged       func(bool)
 	OnFocusGained   func()
 	OnFocusLost     func()
 	OnTypedRune     func(rune) (block bool)
 	OnTypedKey      func(*fyne.KeyEvent) (block bool)
 	OnTypedShortcut func(fyne.Shortcut) (block bool)
+
+	readOnly bool
+
+	binder dataBinder
 }
 
 func NewCheck(text string, changed func(bool)) *Check {
-	c := &Check{}
+	c := &Check{OnChanged: changed}
 	c.Check = widget.Check{
 		DisableableWidget: widget.DisableableWidget{},
 		Text:              text,
-		OnChanged:         changed,
+		OnChanged:         c.onChanged,
 	}
 	c.ToolTipable.parent = c
 	c.ExtendBaseWidget(c)
 	return c
 }
 
+// Bind connects the check to a bool data source.
+// Changes of the data update the check, and toggling the check writes to the data.
+//
+// Unlike widget.Check.Bind, OnChanged is kept.
+func (c *Check) Bind(data binding.Bool) {
+	c.binder.bind(data, func() {
+		if b, err := data.Get(); err == nil {
+			c.SetChecked(b)
+		}
+	})
+}
+
+// Unbind disconnects the data source set by Bind.
+// The check keeps its current value.
+func (c *Check) Unbind() {
+	c.binder.unbind()
+}
+
+// ReadOnly returns read-only status.
+//
+// Read-Only widget will display like a normal widget, but it will be impossible to toggle it.
+func (c *Check) ReadOnly() bool { return c.readOnly }
+
+// SetReadOnly sets read-only status.
+//
+// Read-Only widget will display like a normal widget, but it will be impossible to toggle it.
+func (c *Check) SetReadOnly(b bool) { c.readOnly = b }
+
+// Tapped is called when a pointer tapped event is captured and triggers any change handler
+func (c *Check) Tapped(pe *fyne.PointEvent) {
+	if c.readOnly {
+		return
+	}
+	c.Check.Tapped(pe)
+}
+
 // FocusGained is a hook called by the focus handling logic after this object gained the focus.
 func (c *Check) FocusGained() {
 	c.Check.FocusGained()
@@ -49,6 +91,9 @@ func (c *Check) FocusLost() {
 
 // TypedRune is a hook called by the input handling logic on text input events if this object is focused.
 func (c *Check) TypedRune(r rune) {
+	if c.readOnly {
+		return
+	}
 	if c.OnTypedRune != nil && c.OnTypedRune(r) {
 		return
 	}
@@ -74,6 +119,13 @@ func (c *Check) TypedShortcut(s fyne.Shortcut) {
 	}
 }
 
+func (c *Check) onChanged(b bool) {
+	if c.OnChanged != nil {
+		c.OnChanged(b)
+	}
+	c.binder.toData(func() { c.binder.data.(binding.Bool).Set(b) })
+}
+
 // MouseIn is a hook that is called if the mouse pointer enters the element.
 func (c *Check) MouseIn(me *desktop.MouseEvent) {
 	c.ToolTipable.MouseIn(me)
@@ -0,0 +1,67 @@
+package wx
+
+import (
+	"fyne.io/fyne/v2/widget"
+)
+
+// CheckGroup is a widget.CheckGroup that can be set read-only.
+type CheckGroup struct {
+	widget.CheckGroup
+
+	OnChanged func([]string)
+
+	readOnly bool
+	selected []string // last selection set by code or accepted from the user (see SetReadOnly)
+}
+
+func NewCheckGroup(options []string, changed func([]string)) *CheckGroup {
+	r := &CheckGroup{OnChanged: changed}
+	r.Options = options
+	r.CheckGroup.OnChanged = r.onChanged
+	r.ExtendBaseWidget(r)
+	return r
+}
+
+// ReadOnly returns read-only status.
+//
+// Read-Only widget will display like a normal widget, but it will be impossible to change its selection.
+func (r *CheckGroup) ReadOnly() bool { return r.readOnly }
+
+// SetReadOnly sets read-only status.
+//
+// Read-Only widget will display like a normal widget, but it will be impossible to change its selection.
+// The selection can still be changed by code (see SetSelected).
+func (r *CheckGroup) SetReadOnly(b bool) {
+	r.readOnly = b
+	r.selected = append([]string(nil), r.Selected...)
+}
+
+// SetSelected sets the checked options, even if the group is read-only.
+func (r *CheckGroup) SetSelected(options []string) {
+	r.selected = append([]string(nil), options...)
+	r.CheckGroup.SetSelected(options)
+}
+
+func (r *CheckGroup) onChanged(s []string) {
+	if r.readOnly && !equalStrings(s, r.selected) {
+		// tapped by the user: widget.CheckGroup refreshes the items from Selected after this callback
+		r.Selected = append([]string(nil), r.selected...) // copy, CheckGroup modifies Selected in place
+		return
+	}
+	r.selected = append([]string(nil), s...)
+	if r.OnChanged != nil {
+		r.OnChanged(s)
+	}
+}
+
+func equalStrings(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -1,17 +1,28 @@
 package wx
 
 import (
+	"errors"
 	"fmt"
 	"strconv"
+	"strings"
 	"time"
+	"unicode"
 
 	"fyne.io/fyne/v2"
 	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/data/binding"
 	"fyne.io/fyne/v2/driver/desktop"
+	"fyne.io/fyne/v2/lang"
 	"fyne.io/fyne/v2/theme"
 	"fyne.io/fyne/v2/widget"
 )
 
+// DateEntry is a masked date input (see DateFormat).
+//
+// Shorthands are completed on Enter and FocusLost: a partially typed date (15/__/____ is the 15th of
+// the current month, 15/03/____ of the current year, 15/03/24__ has a two-digit year, see YearPivot),
+// and, starting from an empty entry, words (t, or today, tomorrow... in the user's language, see
+// DateShorthandWords) and offsets from today (+7, -3m, +2w, +1y).
 type DateEntry struct {
 	widget.Entry
 
@@ -19,6 +30,17 @@ type DateEntry struct {
 
 	lastValidTime time.Time
 
+	Format *DateFormat // order and separator of the fields (DefaultDateFormat if nil, see SetFormat)
+
+	// selectable dates (see Calendar). Stepped, pasted and quick-button dates are adjusted immediately,
+	// typed dates are checked on FocusLost: out of range dates are clamped, disabled dates are rejected
+	// (the date before focus is restored). Validate reports the dates set otherwise.
+	MinDate      time.Time
+	MaxDate      time.Time
+	DisabledDate func(time.Time) bool
+
+	YearPivot int // two-digit years below the pivot are in the 2000s, the others in the 1900s (50 if 0)
+
 	ToolTipable
 
 	// custom callbacks
@@ -29,7 +51,14 @@ type DateEntry struct {
 	OnTypedKey      func(k *fyne.KeyEvent) (block bool)
 	OnTypedShortcut func(s fyne.Shortcut) (block bool)
 
-	readOnly bool
+	readOnly  bool
+	focusVal  string // text when the focus was gained
+	shorthand bool   // Text is a typed shorthand, not the mask (see expandDate)
+
+	invalid             bool
+	onValidationChanged func(error)
+
+	binder dataBinder
 
 	popup *widget.PopUp
 	cal   *Calendar
@@ -44,8 +73,15 @@ func NewDateEntry() *DateEntry {
 	d.ToolTipable.parent = d
 	d.ExtendBaseWidget(d)
 
-	d.Text = "__/__/____"
+	d.Text = d.format().mask()
 	d.Entry.OnChanged = func(s string) {
+		if invalid := d.invalidDate(); invalid != d.invalid {
+			d.invalid = invalid
+			if d.onValidationChanged != nil {
+				d.onValidationChanged(d.Validate())
+			}
+		}
+
 		tm := d.GetTime()
 		if !tm.Equal(d.lastValidTime) {
 			if d.OnChanged != nil {
@@ -54,6 +90,8 @@ func NewDateEntry() *DateEntry {
 			d.lastValidTime = tm
 
 			d.calendarSetWithoutCallback(tm)
+
+			d.binder.toData(func() { d.binder.data.(binding.Item[time.Time]).Set(tm) })
 		}
 	}
 
@@ -64,6 +102,7 @@ func NewDateEntry() *DateEntry {
 		}
 
 		d.popup.Content.(*fyne.Container).Objects = d.popup.Content.(*fyne.Container).Objects[:2] // hide extended buttons
+		setEnabled(d.today, d.selectable(time.Now()))
 		d.popup.ShowAtPosition(d.popupPosition())
 
 		// set date after show, because cal internal widgets are create in CreateRenderer
@@ -71,8 +110,10 @@ func NewDateEntry() *DateEntry {
 	}}
 	d.cal = NewCalendar(time.Now(), time.Time{}, d.SetTime) // Calendar will call onChanged only if selected date actually changed
 
-	d.today = &widget.Button{Text: "Aujourd'hui", Alignment: widget.ButtonAlignCenter, Importance: widget.MediumImportance, OnTapped: func() {
-		d.SetTime(time.Now())
+	d.today = &widget.Button{Text: lang.L("Today"), Alignment: widget.ButtonAlignCenter, Importance: widget.MediumImportance, OnTapped: func() {
+		if tm := d.allowedDate(time.Now(), 0); !tm.IsZero() {
+			d.SetTime(tm)
+		}
 	}}
 	d.clr = &widget.Button{Icon: theme.ContentClearIcon(), OnTapped: func() {
 		d.SetTime(time.Time{})
@@ -86,7 +127,16 @@ func NewDateEntry() *DateEntry {
 						d.cal.SelectedDate = time.Now() // pour éviter les calcules bizarres en partant de la date 0 ...
 					}
 
-					d.cal.SetSelectedDate(d.cal.SelectedDate.AddDate(0, months, days))
+					dir := 1
+					if days < 0 || months < 0 {
+						dir = -1
+					}
+					tm := d.allowedDate(d.cal.SelectedDate.AddDate(0, months, days), dir)
+					if tm.IsZero() {
+						return
+					}
+
+					d.cal.SetSelectedDate(tm)
 					d.cal.SetDisplayedDate(d.cal.SelectedDate)
 
 					d.SetTime(d.cal.SelectedDate)
@@ -103,10 +153,10 @@ func NewDateEntry() *DateEntry {
 				))
 			}
 
-			fnAddLine("7 jours", 7, 0)
-			fnAddLine("10 jours", 10, 0)
-			fnAddLine("1 mois", 0, 1)
-			fnAddLine("3 mois", 0, 3)
+			fnAddLine(lang.N("{{.N}} days", 7, map[string]any{"N": 7}), 7, 0)
+			fnAddLine(lang.N("{{.N}} days", 10, map[string]any{"N": 10}), 10, 0)
+			fnAddLine(lang.N("{{.N}} months", 1, map[string]any{"N": 1}), 0, 1)
+			fnAddLine(lang.N("{{.N}} months", 3, map[string]any{"N": 3}), 0, 3)
 		} else {
 			c.Objects = c.Objects[:2]
 		}
@@ -119,8 +169,25 @@ func (d *DateEntry) SetWeekStart(wd time.Weekday) {
 	d.cal.SetWeekStart(wd)
 }
 
+// SetFormat changes the date format (nil for DefaultDateFormat), the date is kept.
+func (d *DateEntry) SetFormat(f *DateFormat) {
+	tm := d.GetTime()
+	d.Format = f
+	d.shorthand = false
+	if tm.IsZero() {
+		d.Text = d.format().mask()
+		d.CursorColumn = 0
+	} else {
+		d.Text = tm.Format(d.format().Layout())
+		d.CursorColumn = len(d.Text)
+	}
+	d.Refresh()
+}
+
+// SetText sets the date from its digits, in the order of the format (separators are ignored).
 func (d *DateEntry) SetText(s string) {
-	d.Text = "__/__/____"
+	d.shorthand = false
+	d.Text = d.format().mask()
 	d.CursorColumn = 0
 	for _, r := range s {
 		d.TypedRune(r)
@@ -130,7 +197,7 @@ func (d *DateEntry) SetText(s string) {
 }
 
 func (d *DateEntry) GetText() string {
-	tm, err := time.ParseInLocation("02/01/2006", d.Text, time.Local)
+	tm, err := time.ParseInLocation(d.format().Layout(), d.Text, time.Local)
 	if err != nil || tm.IsZero() {
 		return ""
 	}
@@ -138,22 +205,58 @@ func (d *DateEntry) GetText() string {
 }
 
 func (d *DateEntry) SetTime(tm time.Time) {
+	d.shorthand = false
 	if tm.IsZero() {
-		d.Text = "__/__/____"
+		d.Text = d.format().mask()
 		d.CursorColumn = 0
 	} else {
-		d.Text = tm.Format("02/01/2006")
-		d.CursorColumn = 10
+		d.Text = tm.Format(d.format().Layout())
+		d.CursorColumn = len(d.Text)
 	}
 	d.Refresh()
 	d.callOnChanged()
 }
 
 func (d *DateEntry) GetTime() time.Time {
-	tm, _ := time.ParseInLocation("02/01/2006", d.Text, time.Local)
+	tm, _ := time.ParseInLocation(d.format().Layout(), d.Text, time.Local)
 	return tm
 }
 
+// BindTime connects the entry to a time data source (see NewTimeBinding).
+// Changes of the data update the entry, and typed dates are written to the data
+// (zero time for an empty or invalid date).
+func (d *DateEntry) BindTime(data binding.Item[time.Time]) {
+	d.binder.bind(data, func() {
+		if tm, err := data.Get(); err == nil && !tm.Equal(d.GetTime()) {
+			d.SetTime(tm)
+		}
+	})
+}
+
+// Unbind disconnects the data source set by BindTime.
+// The entry keeps its current value.
+func (d *DateEntry) Unbind() {
+	d.binder.unbind()
+}
+
+// Validate returns an error if the date is partially typed, invalid (e.g. 31/02/2024) or not selectable
+// (see MinDate, MaxDate and DisabledDate), then calls the Entry Validator if any.
+func (d *DateEntry) Validate() error {
+	if d.Text != d.format().mask() && d.GetText() == "" {
+		return errors.New(lang.L("Invalid date"))
+	}
+	if tm := d.GetTime(); !tm.IsZero() && !d.selectable(tm) {
+		return errors.New(lang.L("This date is not available"))
+	}
+	return d.Entry.Validate()
+}
+
+// SetOnValidationChanged is intended for parent widgets or containers to hook into the validation.
+func (d *DateEntry) SetOnValidationChanged(callback func(error)) {
+	d.onValidationChanged = callback
+	d.Entry.SetOnValidationChanged(callback)
+}
+
 func (d *DateEntry) Enable() {
 	d.Entry.Enable()
 	d.Entry.ActionItem.(fyne.Disableable).Enable()
@@ -187,7 +290,7 @@ func (d *DateEntry) SetReadOnly(b bool) {
 
 func (d *DateEntry) MinSize() fyne.Size {
 	s := d.Entry.MinSize()
-	s.Width = fyne.MeasureText("00/00/0000", theme.TextSize(), d.TextStyle).Width + 2*theme.InnerPadding() + 2*theme.InputBorderSize() + s.Height // trick! pour ajouter la largeur du bouton d'action
+	s.Width = fyne.MeasureText(strings.ReplaceAll(d.format().mask(), "_", "0"), theme.TextSize(), d.TextStyle).Width + 2*theme.InnerPadding() + 2*theme.InputBorderSize() + s.Height // trick! pour ajouter la largeur du bouton d'action
 	return s
 }
 
@@ -202,6 +305,7 @@ func (d *DateEntry) FocusGained() {
 	if d.readOnly {
 		return
 	}
+	d.focusVal = d.Text
 	d.Entry.FocusGained()
 	if d.OnFocusGained != nil {
 		d.OnFocusGained()
@@ -209,6 +313,10 @@ func (d *DateEntry) FocusGained() {
 }
 
 func (d *DateEntry) FocusLost() {
+	if !d.readOnly {
+		d.expandDate()
+		d.checkDate()
+	}
 	d.Entry.FocusLost()
 	if d.OnFocusLost != nil {
 		d.OnFocusLost()
@@ -220,39 +328,75 @@ func (d *DateEntry) TypedRune(r rune) {
 		return
 	}
 
+	f := d.format()
+	if d.shorthand || d.Text == f.mask() && (unicode.IsLetter(r) || r == '+' || r == '-') {
+		if !d.shorthand {
+			d.shorthand = true
+			d.Text = ""
+			d.CursorColumn = 0
+		}
+		t := []rune(d.Text)
+		d.Text = string(t[:d.CursorColumn]) + string(r) + string(t[d.CursorColumn:])
+		d.CursorColumn += 1
+		d.Refresh()
+		d.callOnChanged()
+		return
+	}
+
 	switch r {
 	case '0', '1', '2', '3', '4', '5', '6', '7', '8', '9':
-		switch d.CursorColumn {
-		// __/__/____ 0, 1, /2, 3, 4, /5, 6, 7, 8, 9 [, 10]
-		case 0, 1, 2, 3, 4, 5, 6, 7, 8, 9:
-			t := []rune(d.Text)
-			if d.CursorColumn == 2 || d.CursorColumn == 5 {
+		// the cursor skips the separators, e.g. __/__/____ 0, 1, /2, 3, 4, /5, 6, 7, 8, 9 [, 10]
+		t := []rune(d.Text)
+		if d.CursorColumn < len(t) {
+			if f.isSeparator(d.CursorColumn) {
 				d.CursorColumn += 1
 			}
 			t[d.CursorColumn] = r
 			d.CursorColumn += 1
-			if d.CursorColumn == 2 || d.CursorColumn == 5 {
+			if f.isSeparator(d.CursorColumn) {
 				d.CursorColumn += 1
 			}
 			d.Text = string(t)
 			d.Refresh()
 			d.callOnChanged()
 		}
+	case '/', '-', '.', ' ':
+		// a separator ends a partially typed day or month, e.g. 1/3 gives 01/03
+		k := f.fieldAt(d.CursorColumn)
+		start, n := f.field(k)
+		t := []rune(d.Text)
+		if k == 'y' || d.CursorColumn <= start || d.CursorColumn >= start+n || strings.ContainsRune(string(t[start:d.CursorColumn]), '_') {
+			return
+		}
+		copy(t[start:start+n], []rune(strings.Repeat("0", start+n-d.CursorColumn)+string(t[start:d.CursorColumn])))
+		d.Text = string(t)
+		d.CursorColumn = start + n + 1
+		d.Refresh()
+		d.callOnChanged()
 	}
 }
 
 func (d *DateEntry) TypedKey(k *fyne.KeyEvent) {
-	if d.OnTypedKey != nil && d.OnTypedKey(k) {
+	if !d.readOnly && (k.Name == fyne.KeyReturn || k.Name == fyne.KeyEnter) {
+		d.expandDate()
+		d.checkDate()
+	}
+	if d.OnTypedKey != nil && d.OnTypedKey(k) || d.cal.forwardKey(d.popup, k) {
 		return
 	}
 
+	f := d.format()
+	if d.shorthand {
+		d.typedShorthandKey(k)
+		return
+	}
 	switch k.Name {
 	case fyne.KeyRight:
 		d.CursorColumn += 1
-		if d.CursorColumn >= 10 {
-			d.CursorColumn = 10
+		if n := len([]rune(d.Text)); d.CursorColumn >= n {
+			d.CursorColumn = n
 		}
-		if d.CursorColumn == 2 || d.CursorColumn == 5 {
+		if f.isSeparator(d.CursorColumn) {
 			d.CursorColumn += 1
 		}
 	case fyne.KeyLeft:
@@ -260,46 +404,47 @@ func (d *DateEntry) TypedKey(k *fyne.KeyEvent) {
 		if d.CursorColumn <= 0 {
 			d.CursorColumn = 0
 		}
-		if d.CursorColumn == 2 || d.CursorColumn == 5 {
+		if f.isSeparator(d.CursorColumn) {
 			d.CursorColumn -= 1
 		}
-	case fyne.KeyUp:
-		switch d.CursorColumn {
-		case 0, 1, 2:
-			d.setDay(d.getDay()+1, true)
-		case 3, 4, 5:
-			d.setMonth(d.getMonth()+1, true)
-		case 6, 7, 8, 9, 10:
-			d.setYear(d.getYear() + 1)
+	case fyne.KeyUp, fyne.KeyDown:
+		delta := 1
+		if k.Name == fyne.KeyDown {
+			delta = -1
 		}
-		d.callOnChanged()
-	case fyne.KeyDown:
-		switch d.CursorColumn {
-		case 0, 1, 2:
-			d.setDay(d.getDay()-1, true)
-		case 3, 4, 5:
-			d.setMonth(d.getMonth()-1, true)
-		case 6, 7, 8, 9, 10:
-			d.setYear(d.getYear() - 1)
+		old := d.Text
+		switch f.fieldAt(d.CursorColumn) {
+		case 'd':
+			d.setDay(d.getDay()+delta, true)
+		case 'm':
+			d.setMonth(d.getMonth()+delta, true)
+		case 'y':
+			d.setYear(d.getYear() + delta)
+		}
+		if tm := d.GetTime(); !tm.IsZero() {
+			if tm = d.allowedDate(tm, delta); tm.IsZero() {
+				d.Text = old
+			} else {
+				d.Text = tm.Format(f.Layout())
+			}
 		}
 		d.callOnChanged()
 	case fyne.KeyBackspace:
-		// __/__/____ 0, 1, /2, 3, 4, /5, 6, 7, 8, 9 [, 10]
+		// the cursor skips the separators, e.g. __/__/____ 0, 1, /2, 3, 4, /5, 6, 7, 8, 9 [, 10]
 		t := []rune(d.Text)
-		switch d.CursorColumn {
-		case 10, 9, 8, 7, 5, 4, 2, 1:
+		if d.CursorColumn > 0 && f.isSeparator(d.CursorColumn-1) {
+			d.CursorColumn -= 1
+		} else if d.CursorColumn > 0 {
 			t[d.CursorColumn-1] = '_'
 			d.CursorColumn -= 1
-			if d.CursorColumn == 3 || d.CursorColumn == 6 {
+			if f.isSeparator(d.CursorColumn - 1) {
 				d.CursorColumn -= 1
 			}
-		case 3, 6:
-			d.CursorColumn -= 1
 		}
 		d.Text = string(t)
 		d.callOnChanged()
 	case fyne.KeyDelete, fyne.KeyEscape:
-		d.Text = "__/__/____"
+		d.Text = f.mask()
 		d.CursorColumn = 0
 		d.callOnChanged()
 	default:
@@ -309,14 +454,23 @@ func (d *DateEntry) TypedKey(k *fyne.KeyEvent) {
 }
 
 func (d *DateEntry) TypedShortcut(shortcut fyne.Shortcut) {
-	if d.OnTypedShortcut != nil && d.OnTypedShortcut(shortcut) {
+	if d.OnTypedShortcut != nil && d.OnTypedShortcut(shortcut) || d.cal.forwardShortcut(d.popup, shortcut) {
 		return
 	}
 
 	if s, ok := shortcut.(*fyne.ShortcutPaste); ok {
+		old := d.Text
 		for _, r := range s.Clipboard.Content() {
 			d.TypedRune(r)
 		}
+		if tm := d.GetTime(); !tm.IsZero() {
+			if tm = d.allowedDate(tm, 0); tm.IsZero() {
+				d.Text = old
+			} else {
+				d.Text = tm.Format(d.format().Layout())
+			}
+			d.Refresh()
+		}
 		d.callOnChanged()
 	} else {
 		d.Entry.TypedShortcut(shortcut)
@@ -329,6 +483,142 @@ func (d *DateEntry) MouseOut()                         { d.ToolTipable.MouseOut(
 
 //
 
+func (d *DateEntry) format() DateFormat {
+	if d.Format != nil {
+		return *d.Format
+	}
+	return DefaultDateFormat
+}
+
+// allowedDate clamps tm to MinDate and MaxDate, then skips the disabled days in the direction dir.
+// It returns the zero time if the date is disabled and dir is 0, or if no date is selectable.
+func (d *DateEntry) allowedDate(tm time.Time, dir int) time.Time {
+	day := func(t time.Time) time.Time { return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.Local) }
+	if !d.MinDate.IsZero() && dateOnly(tm).Before(dateOnly(d.MinDate)) {
+		tm, dir = day(d.MinDate), 1
+	} else if !d.MaxDate.IsZero() && dateOnly(tm).After(dateOnly(d.MaxDate)) {
+		tm, dir = day(d.MaxDate), -1
+	}
+	if dir > 0 {
+		dir = 1
+	} else if dir < 0 {
+		dir = -1
+	}
+
+	for i := 0; !d.selectable(tm); i++ {
+		if dir == 0 || i > 366 {
+			return time.Time{}
+		}
+		tm = tm.AddDate(0, 0, dir)
+		if !d.MinDate.IsZero() && dateOnly(tm).Before(dateOnly(d.MinDate)) ||
+			!d.MaxDate.IsZero() && dateOnly(tm).After(dateOnly(d.MaxDate)) {
+			return time.Time{}
+		}
+	}
+	return tm
+}
+
+// expandDate completes a partially typed date, or evaluates a typed shorthand (see DateEntry).
+// Invalid texts are kept, and reported by Validate.
+func (d *DateEntry) expandDate() {
+	f := d.format()
+	if d.Text == f.mask() || d.GetText() != "" {
+		return
+	}
+
+	var tm time.Time
+	var ok bool
+	if d.shorthand {
+		tm, ok = parseDateShorthand(d.Text, f, time.Now(), d.yearPivot())
+	} else {
+		// the filled fields, e.g. 15/03/____ gives 15, 03
+		var parts []string
+		t := []rune(d.Text)
+		for _, k := range []byte(f.fields()) {
+			start, n := f.field(k)
+			v := strings.TrimRight(string(t[start:start+n]), "_")
+			if v == "" || strings.ContainsRune(v, '_') {
+				break
+			}
+			parts = append(parts, v)
+		}
+		tm, ok = dateFromParts(parts, f, time.Now(), d.yearPivot())
+	}
+	if ok {
+		d.SetTime(tm)
+	}
+}
+
+// typedShorthandKey edits a typed shorthand (see expandDate).
+func (d *DateEntry) typedShorthandKey(k *fyne.KeyEvent) {
+	t := []rune(d.Text)
+	switch k.Name {
+	case fyne.KeyRight:
+		if d.CursorColumn < len(t) {
+			d.CursorColumn += 1
+		}
+	case fyne.KeyLeft:
+		if d.CursorColumn > 0 {
+			d.CursorColumn -= 1
+		}
+	case fyne.KeyBackspace:
+		if d.CursorColumn > 0 {
+			d.Text = string(t[:d.CursorColumn-1]) + string(t[d.CursorColumn:])
+			d.CursorColumn -= 1
+		}
+		if d.Text == "" {
+			d.shorthand = false
+			d.Text = d.format().mask()
+		}
+		d.callOnChanged()
+	case fyne.KeyDelete, fyne.KeyEscape:
+		d.shorthand = false
+		d.Text = d.format().mask()
+		d.CursorColumn = 0
+		d.callOnChanged()
+	default:
+		return
+	}
+	d.Refresh()
+}
+
+func (d *DateEntry) yearPivot() int {
+	if d.YearPivot > 0 {
+		return d.YearPivot
+	}
+	return 50
+}
+
+// checkDate clamps out of range typed dates, and rejects disabled ones.
+func (d *DateEntry) checkDate() {
+	tm := d.GetTime()
+	if tm.IsZero() {
+		return
+	}
+	if adj := d.allowedDate(tm, 0); adj.IsZero() {
+		if d.focusVal != d.Text {
+			d.Text = d.focusVal
+			d.Refresh()
+			d.callOnChanged()
+		}
+	} else if !adj.Equal(tm) {
+		d.SetTime(adj)
+	}
+}
+
+func (d *DateEntry) invalidDate() bool {
+	if d.Text != d.format().mask() && d.GetText() == "" {
+		return true
+	}
+	tm := d.GetTime()
+	return !tm.IsZero() && !d.selectable(tm)
+}
+
+func (d *DateEntry) selectable(tm time.Time) bool {
+	d.cal.MinDate, d.cal.MaxDate, d.cal.DisabledDate = d.MinDate, d.MaxDate, d.DisabledDate
+	return d.cal.Selectable(tm)
+}
+
 func (d *DateEntry) callOnChanged() {
 	d.Entry.OnChanged(d.Text)
 }
@@ -340,6 +630,7 @@ func (d *DateEntry) popupPosition() fyne.Position {
 }
 
 func (d *DateEntry) calendarSetWithoutCallback(date time.Time) {
+	d.cal.MinDate, d.cal.MaxDate, d.cal.DisabledDate = d.MinDate, d.MaxDate, d.DisabledDate
 	oldCB := d.cal.OnChanged
 	d.cal.OnChanged = nil
 	d.cal.SetSelectedDate(date)
@@ -380,14 +671,10 @@ func (d *DateEntry) setDay(day int, loop bool) {
 			day = 1
 		}
 	}
-	d.Text = fmt.Sprintf("%02d", day) + d.Text[2:]
+	d.setField('d', day)
 }
 func (d *DateEntry) getDay() int {
-	ret, err := strconv.Atoi(d.Text[:2]) // __/__/____
-	if err != nil {
-		return 0
-	}
-	return ret
+	return d.getField('d')
 }
 
 func (d *DateEntry) setMonth(month int, loop bool) {
@@ -405,15 +692,10 @@ func (d *DateEntry) setMonth(month int, loop bool) {
 			month = 1
 		}
 	}
-	sMonth := fmt.Sprintf("%02d", month)
-	d.Text = d.Text[:3] + sMonth + d.Text[5:]
+	d.setField('m', month)
 }
 func (d *DateEntry) getMonth() int {
-	ret, err := strconv.Atoi(d.Text[3:5]) // __/__/____
-	if err != nil {
-		return 0
-	}
-	return ret
+	return d.getField('m')
 }
 
 func (d *DateEntry) setYear(year int) {
@@ -423,11 +705,22 @@ func (d *DateEntry) setYear(year int) {
 	if year < 1 {
 		year = 1
 	}
-	sYear := fmt.Sprintf("%04d", year)
-	d.Text = d.Text[:6] + sYear
+	d.setField('y', year)
 }
 func (d *DateEntry) getYear() int {
-	ret, err := strconv.Atoi(d.Text[6:]) // __/__/____
+	return d.getField('y')
+}
+
+// setField replaces the digits of a field ('d', 'm' or 'y').
+func (d *DateEntry) setField(k byte, v int) {
+	start, n := d.format().field(k)
+	t := []rune(d.Text)
+	copy(t[start:start+n], []rune(fmt.Sprintf("%0*d", n, v)))
+	d.Text = string(t)
+}
+func (d *DateEntry) getField(k byte) int {
+	start, n := d.format().field(k)
+	ret, err := strconv.Atoi(string([]rune(d.Text)[start : start+n]))
 	if err != nil {
 		return 0
 	}
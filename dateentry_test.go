@@ -0,0 +1,365 @@
+package wx
+
+import (
+	"testing"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/driver/desktop"
+	"fyne.io/fyne/v2/lang"
+	"fyne.io/fyne/v2/test"
+	"fyne.io/fyne/v2/widget"
+)
+
+func TestDateEntryFormat(t *testing.T) {
+	test.NewTempApp(t)
+
+	d := NewDateEntry()
+	d.SetFormat(&DateFormatISO)
+	if d.Text != "____-__-__" {
+		t.Fatalf("unexpected mask %s", d.Text)
+	}
+	test.Type(d, "20240115")
+	if d.Text != "2024-01-15" || !d.GetTime().Equal(time.Date(2024, 1, 15, 0, 0, 0, 0, time.Local)) {
+		t.Fatalf("unexpected date %s", d.Text)
+	}
+
+	d.CursorColumn = 6 // month
+	d.TypedKey(&fyne.KeyEvent{Name: fyne.KeyUp})
+	d.CursorColumn = 10 // day
+	d.TypedKey(&fyne.KeyEvent{Name: fyne.KeyDown})
+	d.CursorColumn = 0 // year
+	d.TypedKey(&fyne.KeyEvent{Name: fyne.KeyUp})
+	if d.GetText() != "2025-02-14" {
+		t.Fatalf("unexpected date %s", d.Text)
+	}
+
+	d.SetFormat(&DateFormatMDY)
+	if d.Text != "02/14/2025" {
+		t.Fatalf("unexpected date %s", d.Text)
+	}
+	d.TypedKey(&fyne.KeyEvent{Name: fyne.KeyBackspace})
+	d.TypedKey(&fyne.KeyEvent{Name: fyne.KeyBackspace})
+	d.TypedKey(&fyne.KeyEvent{Name: fyne.KeyBackspace})
+	d.TypedKey(&fyne.KeyEvent{Name: fyne.KeyBackspace})
+	d.TypedKey(&fyne.KeyEvent{Name: fyne.KeyBackspace})
+	if d.Text != "02/1_/____" || d.CursorColumn != 4 {
+		t.Fatalf("unexpected text %s (%d)", d.Text, d.CursorColumn)
+	}
+}
+
+func TestDateEntryConstraints(t *testing.T) {
+	test.NewTempApp(t)
+
+	d := NewDateEntry()
+	d.SetFormat(&DateFormatDMY)
+	d.MinDate = time.Date(2024, 1, 10, 0, 0, 0, 0, time.Local)
+	d.MaxDate = time.Date(2024, 12, 31, 0, 0, 0, 0, time.Local)
+	d.DisabledDate = func(t time.Time) bool { return t.Weekday() == time.Saturday || t.Weekday() == time.Sunday }
+
+	d.FocusGained()
+	test.Type(d, "05012024")
+	if d.Validate() == nil {
+		t.Fatal("date before MinDate should be invalid")
+	}
+	d.FocusLost()
+	if d.GetText() != "10/01/2024" {
+		t.Fatalf("date should be clamped: %s", d.Text)
+	}
+
+	d.FocusGained()
+	d.SetText("13012024") // saturday
+	d.FocusLost()
+	if d.GetText() != "10/01/2024" {
+		t.Fatalf("disabled date should be rejected: %s", d.Text)
+	}
+
+	d.SetText("12012024") // friday
+	d.CursorColumn = 0
+	d.TypedKey(&fyne.KeyEvent{Name: fyne.KeyUp})
+	if d.GetText() != "15/01/2024" {
+		t.Fatalf("weekend should be skipped: %s", d.Text)
+	}
+	d.CursorColumn = 9
+	d.TypedKey(&fyne.KeyEvent{Name: fyne.KeyUp})
+	if d.GetText() != "31/12/2024" {
+		t.Fatalf("date should be clamped to MaxDate: %s", d.Text)
+	}
+
+	today := NewDateEntry()
+	today.MinDate = time.Now().AddDate(0, 0, 3)
+	test.NewTempWindow(t, today)
+	test.Tap(today.ActionItem.(*widget.Button))
+	if !today.today.Disabled() {
+		t.Fatal("today button should be disabled before MinDate")
+	}
+	today.today.OnTapped()
+	if tm := today.GetTime(); !tm.IsZero() && today.Validate() != nil {
+		t.Fatalf("today button should not set an unavailable date: %s", today.Text)
+	}
+
+	c := NewCalendar(d.MinDate, time.Time{}, nil)
+	c.MinDate = d.MinDate
+	test.NewTempWindow(t, c)
+	if !c.monthPrevious.Disabled() || c.monthNext.Disabled() {
+		t.Fatal("previous month should be disabled")
+	}
+}
+
+func TestDateRangeCalendar(t *testing.T) {
+	test.NewTempApp(t)
+
+	var from, to time.Time
+	r := NewDateRangeCalendar(time.Time{}, time.Time{}, true, func(f, t time.Time) { from, to = f, t })
+	test.NewTempWindow(t, r)
+	r.SetDisplayedDate(time.Date(2024, 3, 1, 0, 0, 0, 0, time.Local))
+	if r.cals[1].displayedDate.Month() != time.April {
+		t.Fatalf("second month should be april: %v", r.cals[1].displayedDate)
+	}
+
+	d20, d5 := time.Date(2024, 3, 20, 0, 0, 0, 0, time.Local), time.Date(2024, 4, 5, 0, 0, 0, 0, time.Local)
+	r.tapped(d5)
+	r.dayHovered(d20)
+	if imp, ok := r.dayImportance(time.Date(2024, 3, 25, 0, 0, 0, 0, time.Local)); !ok || imp != widget.MediumImportance {
+		t.Fatal("hovered range should be highlighted")
+	}
+	if !from.IsZero() {
+		t.Fatal("OnChanged should be called on the second tap")
+	}
+	r.tapped(d20)
+	if !from.Equal(d20) || !to.Equal(d5) {
+		t.Fatalf("unexpected range %v - %v", from, to)
+	}
+}
+
+func TestDateRangeEntry(t *testing.T) {
+	test.NewTempApp(t)
+
+	calls := 0
+	r := NewDateRangeEntry()
+	r.SetFormat(&DateFormatDMY)
+	r.OnChanged = func(_, _ time.Time) { calls++ }
+	r.SetText("01/03/2024 - 31/03/2024")
+	if calls != 1 || r.GetText() != "01/03/2024 - 31/03/2024" {
+		t.Fatalf("unexpected range %s (%d calls)", r.GetText(), calls)
+	}
+
+	r.To().SetText("01022024")
+	if r.Validate() == nil {
+		t.Fatal("end date before start date should be invalid")
+	}
+}
+
+func TestTimeEntry(t *testing.T) {
+	test.NewTempApp(t)
+
+	e := NewTimeEntry()
+	test.Type(e, "0930")
+	if e.Text != "09:30" || e.GetTime().Hour() != 9 || e.GetTime().Minute() != 30 {
+		t.Fatalf("unexpected time %s", e.Text)
+	}
+
+	e.CursorColumn = 0 // hour
+	e.TypedKey(&fyne.KeyEvent{Name: fyne.KeyDown})
+	e.CursorColumn = 4 // minute
+	e.TypedKey(&fyne.KeyEvent{Name: fyne.KeyUp})
+	if e.GetText() != "08:31" {
+		t.Fatalf("unexpected time %s", e.Text)
+	}
+
+	e.SetFormat(&TimeFormat{Seconds: true, Hour12: true})
+	if e.Text != "08:31:00 AM" {
+		t.Fatalf("unexpected time %s", e.Text)
+	}
+	e.CursorColumn = 10 // AM/PM
+	e.TypedKey(&fyne.KeyEvent{Name: fyne.KeyUp})
+	if e.GetTime().Hour() != 20 {
+		t.Fatalf("unexpected time %s", e.Text)
+	}
+
+	e.SetText("25")
+	if e.Validate() == nil {
+		t.Fatal("partial time must be invalid")
+	}
+}
+
+func TestDateTimeEntry(t *testing.T) {
+	test.NewTempApp(t)
+
+	paris, err := time.LoadLocation("Europe/Paris")
+	if err != nil {
+		t.Skip(err)
+	}
+	e := NewDateTimeEntry()
+	e.Date().SetFormat(&DateFormatDMY)
+	e.Location = time.UTC
+	var changed int
+	e.OnChanged = func(_ time.Time) { changed++ }
+
+	e.SetText("15/01/2024 23:30")
+	if tm := e.GetTime(); !tm.Equal(time.Date(2024, 1, 15, 23, 30, 0, 0, time.UTC)) || changed != 1 {
+		t.Fatalf("unexpected time %v (%d)", tm, changed)
+	}
+
+	e.SetLocation(paris) // same instant, next day in Paris
+	if e.GetText() != "16/01/2024 00:30" || !e.GetTime().Equal(time.Date(2024, 1, 15, 23, 30, 0, 0, time.UTC)) {
+		t.Fatalf("unexpected text %s", e.GetText())
+	}
+
+	e.Date().SetTime(time.Time{})
+	if e.Validate() == nil {
+		t.Fatal("time without date must be invalid")
+	}
+}
+
+func TestDateEntryShorthand(t *testing.T) {
+	test.NewTempApp(t)
+
+	now := time.Now()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.Local)
+	enter := &fyne.KeyEvent{Name: fyne.KeyReturn}
+
+	d := NewDateEntry()
+	d.SetFormat(&DateFormatDMY)
+	test.Type(d, "t")
+	d.TypedKey(enter)
+	if !d.GetTime().Equal(today) {
+		t.Fatalf("unexpected date %s", d.Text)
+	}
+
+	for s, want := range map[string]time.Time{
+		lang.L("Tomorrow"):         today.AddDate(0, 0, 1),
+		lang.L("Before yesterday"): today.AddDate(0, 0, -2),
+		"+7":                       today.AddDate(0, 0, 7),
+		"-3m":                      today.AddDate(0, -3, 0),
+		"+2w":                      today.AddDate(0, 0, 14),
+	} {
+		d.SetTime(time.Time{})
+		test.Type(d, s)
+		d.TypedKey(enter)
+		if !d.GetTime().Equal(want) {
+			t.Fatalf("%s: unexpected date %s", s, d.Text)
+		}
+	}
+
+	d.SetTime(time.Time{})
+	test.Type(d, "15")
+	d.TypedKey(enter)
+	if !d.GetTime().Equal(time.Date(now.Year(), now.Month(), 15, 0, 0, 0, 0, time.Local)) {
+		t.Fatalf("unexpected date %s", d.Text)
+	}
+
+	d.SetTime(time.Time{})
+	test.Type(d, "1/3")
+	if d.Text != "01/3_/____" {
+		t.Fatalf("unexpected text %s", d.Text)
+	}
+	d.FocusLost()
+	if !d.GetTime().Equal(time.Date(now.Year(), 3, 1, 0, 0, 0, 0, time.Local)) {
+		t.Fatalf("unexpected date %s", d.Text)
+	}
+
+	d.YearPivot = 30
+	d.SetText("150324")
+	d.TypedKey(enter)
+	if d.GetText() != "15/03/2024" {
+		t.Fatalf("unexpected date %s", d.Text)
+	}
+	d.SetText("150345")
+	d.TypedKey(enter)
+	if d.GetText() != "15/03/1945" {
+		t.Fatalf("unexpected date %s", d.Text)
+	}
+
+	d.SetTime(time.Time{})
+	test.Type(d, "xyz")
+	d.TypedKey(enter)
+	if d.Text != "xyz" || d.Validate() == nil {
+		t.Fatalf("invalid shorthand should be kept: %s", d.Text)
+	}
+	d.TypedKey(&fyne.KeyEvent{Name: fyne.KeyEscape})
+	if d.Text != "__/__/____" {
+		t.Fatalf("unexpected text %s", d.Text)
+	}
+}
+
+func TestCalendarNavigation(t *testing.T) {
+	test.NewTempApp(t)
+
+	c := NewCalendar(time.Date(2024, 3, 15, 0, 0, 0, 0, time.Local), time.Time{}, nil)
+	c.MinDate = time.Date(1960, 6, 1, 0, 0, 0, 0, time.Local)
+	test.NewTempWindow(t, c)
+
+	test.Tap(c.monthLabel)
+	if c.monthLabel.Text != "2024" || len(c.dates.Objects) != 12 {
+		t.Fatalf("months view expected: %s", c.monthLabel.Text)
+	}
+	test.Tap(c.monthLabel)
+	if c.monthLabel.Text != "2020 – 2029" {
+		t.Fatalf("years view expected: %s", c.monthLabel.Text)
+	}
+	for i := 0; i < 7; i++ {
+		test.Tap(c.monthPrevious)
+	}
+	if c.monthLabel.Text != "1960 – 1969" || !c.monthPrevious.Disabled() {
+		t.Fatalf("decade should be clamped to MinDate: %s", c.monthLabel.Text)
+	}
+	if !c.dates.Objects[0].(*widget.Button).Disabled() { // 1959
+		t.Fatal("years before MinDate should be disabled")
+	}
+
+	test.Tap(c.dates.Objects[3].(*widget.Button)) // 1962
+	test.Tap(c.dates.Objects[4].(*widget.Button)) // May
+	if c.view != viewDays || c.displayedDate.Year() != 1962 || c.displayedDate.Month() != time.May {
+		t.Fatalf("unexpected displayed date %v", c.displayedDate)
+	}
+
+	c.TypedKey(&fyne.KeyEvent{Name: fyne.KeyPageDown})
+	c.TypedShortcut(&desktop.CustomShortcut{KeyName: fyne.KeyPageUp, Modifier: fyne.KeyModifierControl})
+	c.TypedShortcut(&desktop.CustomShortcut{KeyName: fyne.KeyPageUp, Modifier: fyne.KeyModifierControl})
+	if c.displayedDate.Year() != 1960 || c.displayedDate.Month() != time.June {
+		t.Fatalf("unexpected displayed date %v", c.displayedDate)
+	}
+	c.TypedKey(&fyne.KeyEvent{Name: fyne.KeyPageUp})
+	if c.displayedDate.Month() != time.June {
+		t.Fatalf("month before MinDate should not be displayed: %v", c.displayedDate)
+	}
+}
+
+func TestTranslations(t *testing.T) {
+	if err := lang.AddTranslationsFS(translations, "translations"); err != nil {
+		t.Fatal(err)
+	}
+	if lang.SystemLocale().LanguageString() == "en" {
+		if s := lang.N("{{.N}} months", 1, map[string]any{"N": 1}); s != "1 month" {
+			t.Fatalf("unexpected singular %s", s)
+		}
+		if s := lang.N("{{.N}} months", 3, map[string]any{"N": 3}); s != "3 months" {
+			t.Fatalf("unexpected plural %s", s)
+		}
+	}
+}
+
+func TestDateEntryCalendarKeys(t *testing.T) {
+	test.NewTempApp(t)
+
+	d := NewDateEntry()
+	d.SetText("15/03/2024")
+	test.NewTempWindow(t, d)
+	d.TypedKey(&fyne.KeyEvent{Name: fyne.KeyPageDown}) // no popup: ignored
+	test.Tap(d.ActionItem.(*widget.Button))
+	d.TypedKey(&fyne.KeyEvent{Name: fyne.KeyPageDown})
+	d.TypedShortcut(&desktop.CustomShortcut{KeyName: fyne.KeyPageUp, Modifier: fyne.KeyModifierControl})
+	if m := d.cal.displayedDate; m.Year() != 2023 || m.Month() != time.April || d.Text != "15/03/2024" {
+		t.Fatalf("unexpected displayed month %v (%s)", m, d.Text)
+	}
+
+	r := NewDateRangeEntry()
+	r.From().SetText("15/03/2024")
+	test.NewTempWindow(t, r)
+	test.Tap(r.From().ActionItem.(*widget.Button))
+	r.From().TypedKey(&fyne.KeyEvent{Name: fyne.KeyPageDown})
+	if m := r.cal.cals[1].displayedDate; m.Month() != time.May {
+		t.Fatalf("unexpected displayed month %v", m)
+	}
+}
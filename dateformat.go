@@ -0,0 +1,129 @@
+package wx
+
+import (
+	"strings"
+
+	"fyne.io/fyne/v2"
+)
+
+// DateOrder is the order of the day, month and year fields of a DateFormat.
+type DateOrder int
+
+const (
+	DMY DateOrder = iota // 31/12/2024
+	MDY                  // 12/31/2024
+	YMD                  // 2024-12-31
+)
+
+// DateFormat describes how a DateEntry reads and displays dates.
+type DateFormat struct {
+	Order     DateOrder
+	Separator rune // '/' if 0
+}
+
+var (
+	DateFormatDMY = DateFormat{Order: DMY, Separator: '/'}
+	DateFormatMDY = DateFormat{Order: MDY, Separator: '/'}
+	DateFormatISO = DateFormat{Order: YMD, Separator: '-'} // ISO 8601
+)
+
+// DefaultDateFormat is the format of the DateEntry without Format.
+//
+// Applications can use the format of the user's language:
+//
+//	wx.DefaultDateFormat = wx.LocaleDateFormat(lang.SystemLocale())
+var DefaultDateFormat = DateFormatDMY
+
+// LocaleDateFormat returns the usual numeric date format of a locale (e.g. "en-US" or "fr").
+func LocaleDateFormat(l fyne.Locale) DateFormat {
+	language, region, _ := strings.Cut(strings.ReplaceAll(string(l), "_", "-"), "-")
+	switch {
+	case language == "en" && (region == "" || region == "US" || region == "PH"):
+		return DateFormatMDY
+	case language == "sv" || language == "lt":
+		return DateFormatISO
+	case language == "zh" || language == "ja":
+		return DateFormat{Order: YMD, Separator: '/'}
+	case language == "hu" || language == "ko":
+		return DateFormat{Order: YMD, Separator: '.'}
+	case language == "nl":
+		return DateFormat{Order: DMY, Separator: '-'}
+	case strings.Contains(" de ru pl cs sk fi nb da tr uk ro ", " "+language+" "):
+		return DateFormat{Order: DMY, Separator: '.'}
+	}
+	return DateFormatDMY
+}
+
+// Layout returns the time layout of the format (e.g. "02/01/2006"), see time.Format.
+func (f DateFormat) Layout() string {
+	parts := map[byte]string{'d': "02", 'm': "01", 'y': "2006"}
+	return f.join(parts)
+}
+
+// ----------------------------------------------------------------------------
+// internals
+
+var dateFieldLen = map[byte]int{'d': 2, 'm': 2, 'y': 4}
+
+// fields returns the fields in display order ('d', 'm', 'y').
+func (f DateFormat) fields() string {
+	switch f.Order {
+	case MDY:
+		return "mdy"
+	case YMD:
+		return "ymd"
+	}
+	return "dmy"
+}
+
+func (f DateFormat) separator() rune {
+	if f.Separator == 0 {
+		return '/'
+	}
+	return f.Separator
+}
+
+func (f DateFormat) join(parts map[byte]string) string {
+	var b strings.Builder
+	for i, k := range []byte(f.fields()) {
+		if i > 0 {
+			b.WriteRune(f.separator())
+		}
+		b.WriteString(parts[k])
+	}
+	return b.String()
+}
+
+// mask returns the text of an empty date (e.g. "__/__/____").
+func (f DateFormat) mask() string {
+	return f.join(map[byte]string{'d': "__", 'm': "__", 'y': "____"})
+}
+
+// field returns the position (in runes) of a field in the text.
+func (f DateFormat) field(k byte) (start, n int) {
+	for _, c := range []byte(f.fields()) {
+		if c == k {
+			return start, dateFieldLen[c]
+		}
+		start += dateFieldLen[c] + 1
+	}
+	return
+}
+
+// fieldAt returns the field at a cursor position (the cursor can be just after the field).
+func (f DateFormat) fieldAt(col int) byte {
+	end := -1
+	for _, c := range []byte(f.fields()) {
+		end += dateFieldLen[c] + 1
+		if col <= end {
+			return c
+		}
+	}
+	return f.fields()[2]
+}
+
+// isSeparator returns wether the rune at a cursor position is a separator.
+func (f DateFormat) isSeparator(col int) bool {
+	mask := []rune(f.mask())
+	return col >= 0 && col < len(mask) && mask[col] != '_'
+}
@@ -0,0 +1,439 @@
+package wx
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/lang"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+)
+
+// DateRangeCalendar selects a period: the first tap selects the start date, the second one the end date
+// (the range is previewed while hovering the days).
+//
+// Presets (this week, last month, year to date) are displayed under the calendars.
+type DateRangeCalendar struct {
+	widget.BaseWidget
+
+	// selectable dates (see Calendar)
+	MinDate      time.Time
+	MaxDate      time.Time
+	DisabledDate func(time.Time) bool
+
+	OnChanged func(from, to time.Time)
+
+	from, to time.Time
+	picking  bool      // the start date is selected, waiting for the end date
+	start    time.Time // start date while picking
+	hovered  time.Time
+	cals     []*Calendar
+	syncing  bool
+}
+
+// NewDateRangeCalendar creates a range calendar, displaying two months side by side if twoMonths is true.
+func NewDateRangeCalendar(from, to time.Time, twoMonths bool, changed func(from, to time.Time)) *DateRangeCalendar {
+	r := &DateRangeCalendar{OnChanged: changed}
+	r.ExtendBaseWidget(r)
+
+	n := 1
+	if twoMonths {
+		n = 2
+	}
+	now := time.Now()
+	for i := 0; i < n; i++ {
+		i := i
+		c := NewCalendar(time.Date(now.Year(), now.Month()+time.Month(i), 1, 0, 0, 0, 0, time.Local), time.Time{}, nil) // not AddDate: Jan 31 + 1 month is in March
+		c.dayImportance = r.dayImportance
+		c.onDayTapped = r.tapped
+		c.onDayHovered = r.dayHovered
+		c.onDisplayed = func(t time.Time) { r.displayed(i, t) }
+		r.cals = append(r.cals, c)
+	}
+	r.SetRange(from, to)
+	return r
+}
+
+func (r *DateRangeCalendar) CreateRenderer() fyne.WidgetRenderer {
+	r.syncConstraints()
+
+	months := make([]fyne.CanvasObject, len(r.cals))
+	for i, c := range r.cals {
+		months[i] = c
+	}
+	presets := container.NewGridWithColumns(3,
+		widget.NewButton(lang.L("This week"), func() { r.preset(r.thisWeek()) }),
+		widget.NewButton(lang.L("Last month"), func() { r.preset(r.lastMonth()) }),
+		widget.NewButton(lang.L("Year to date"), func() { r.preset(r.yearToDate()) }),
+	)
+	return widget.NewSimpleRenderer(container.NewBorder(nil, presets, nil, nil, container.NewGridWithColumns(len(months), months...)))
+}
+
+// GetRange returns the selected period (zero times if none).
+func (r *DateRangeCalendar) GetRange() (from, to time.Time) {
+	return r.from, r.to
+}
+
+// SetRange selects a period (the dates are swapped if to is before from), and displays its start.
+func (r *DateRangeCalendar) SetRange(from, to time.Time) {
+	if !from.IsZero() && !to.IsZero() && to.Before(from) {
+		from, to = to, from
+	}
+	r.from, r.to, r.picking = from, to, false
+	if !from.IsZero() {
+		r.SetDisplayedDate(from)
+	} else {
+		r.update()
+	}
+}
+
+// SetDisplayedDate sets the month displayed by the first calendar.
+func (r *DateRangeCalendar) SetDisplayedDate(date time.Time) {
+	r.syncConstraints()
+	r.cals[0].SetDisplayedDate(date)
+	r.update()
+}
+
+// ----------------------------------------------------------------------------
+// internals
+
+func (r *DateRangeCalendar) syncConstraints() {
+	for _, c := range r.cals {
+		c.MinDate, c.MaxDate, c.DisabledDate = r.MinDate, r.MaxDate, r.DisabledDate
+	}
+}
+
+// displayed keeps the calendars on consecutive months.
+func (r *DateRangeCalendar) displayed(i int, t time.Time) {
+	if r.syncing {
+		return
+	}
+	r.syncing = true
+	for j, c := range r.cals {
+		if j != i {
+			c.SetDisplayedDate(t.AddDate(0, j-i, 0))
+		}
+	}
+	r.syncing = false
+}
+
+func (r *DateRangeCalendar) tapped(date time.Time) {
+	if !r.picking {
+		r.picking, r.start, r.hovered = true, date, date
+		r.update()
+		return
+	}
+
+	r.picking = false
+	r.setRange(r.start, date)
+}
+
+func (r *DateRangeCalendar) dayHovered(date time.Time) {
+	if r.picking && !date.Equal(r.hovered) {
+		r.hovered = date
+		r.update()
+	}
+}
+
+// dayImportance highlights the bounds (high importance) and the days (medium importance) of the range.
+func (r *DateRangeCalendar) dayImportance(date time.Time) (widget.Importance, bool) {
+	from, to := r.from, r.to
+	if r.picking {
+		from, to = r.start, r.hovered
+		if to.Before(from) {
+			from, to = to, from
+		}
+	}
+	if from.IsZero() {
+		return 0, false
+	}
+
+	day := dateOnly(date)
+	switch {
+	case day.Equal(dateOnly(from)) || !to.IsZero() && day.Equal(dateOnly(to)):
+		return widget.HighImportance, true
+	case !to.IsZero() && day.After(dateOnly(from)) && day.Before(dateOnly(to)):
+		return widget.MediumImportance, true
+	}
+	return 0, false
+}
+
+func (r *DateRangeCalendar) update() {
+	for _, c := range r.cals {
+		c.updateSelection()
+	}
+}
+
+// setRange selects a period and calls OnChanged.
+func (r *DateRangeCalendar) setRange(from, to time.Time) {
+	r.SetRange(from, to)
+	if r.OnChanged != nil {
+		r.OnChanged(r.from, r.to)
+	}
+}
+
+// preset selects a period, restricted to MinDate and MaxDate.
+func (r *DateRangeCalendar) preset(from, to time.Time) {
+	if !r.MinDate.IsZero() && dateOnly(from).Before(dateOnly(r.MinDate)) {
+		from = r.MinDate
+	}
+	if !r.MaxDate.IsZero() && dateOnly(to).After(dateOnly(r.MaxDate)) {
+		to = r.MaxDate
+	}
+	if dateOnly(to).Before(dateOnly(from)) {
+		return
+	}
+	r.setRange(from, to)
+}
+
+func (r *DateRangeCalendar) thisWeek() (from, to time.Time) {
+	today := dateOnlyLocal(time.Now())
+	from = today.AddDate(0, 0, -((int(today.Weekday()) - int(r.cals[0].WeekStart) + daysPerWeek) % daysPerWeek))
+	return from, from.AddDate(0, 0, daysPerWeek-1)
+}
+
+func (r *DateRangeCalendar) lastMonth() (from, to time.Time) {
+	now := time.Now()
+	from = time.Date(now.Year(), now.Month()-1, 1, 0, 0, 0, 0, time.Local)
+	return from, from.AddDate(0, 1, -1)
+}
+
+func (r *DateRangeCalendar) yearToDate() (from, to time.Time) {
+	to = dateOnlyLocal(time.Now())
+	return time.Date(to.Year(), 1, 1, 0, 0, 0, 0, time.Local), to
+}
+
+func dateOnlyLocal(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.Local)
+}
+
+// ----------------------------------------------------------------------------
+// DateRangeEntry
+
+// DateRangeEntry is a period input: two DateEntry (start and end dates) sharing a DateRangeCalendar popup.
+type DateRangeEntry struct {
+	widget.DisableableWidget
+
+	// selectable dates (see DateEntry)
+	MinDate      time.Time
+	MaxDate      time.Time
+	DisabledDate func(time.Time) bool
+
+	OnChanged func(from, to time.Time)
+
+	// custom callbacks, forwarded from the entries
+	OnTypedKey      func(*fyne.KeyEvent) (block bool)
+	OnTypedShortcut func(fyne.Shortcut) (block bool)
+
+	from, to *DateEntry
+	cal      *DateRangeCalendar
+	popup    *widget.PopUp
+
+	lastFrom, lastTo    time.Time
+	updating            bool
+	readOnly            bool
+	invalid             bool
+	onValidationChanged func(error)
+}
+
+func NewDateRangeEntry() *DateRangeEntry {
+	r := &DateRangeEntry{from: NewDateEntry(), to: NewDateEntry()}
+	r.ExtendBaseWidget(r)
+
+	for _, d := range []*DateEntry{r.from, r.to} {
+		d.OnChanged = func(_ time.Time) { r.changed() }
+		d.OnFocusGained = r.syncConstraints
+		d.OnTypedKey = func(ke *fyne.KeyEvent) bool {
+			return r.OnTypedKey != nil && r.OnTypedKey(ke) || r.cal.cals[0].forwardKey(r.popup, ke)
+		}
+		d.OnTypedShortcut = func(s fyne.Shortcut) bool {
+			return r.OnTypedShortcut != nil && r.OnTypedShortcut(s) || r.cal.cals[0].forwardShortcut(r.popup, s)
+		}
+		d.SetOnValidationChanged(func(_ error) { r.validityChanged() })
+		d.ActionItem = &widget.Button{Icon: theme.CalendarIcon(), Importance: widget.LowImportance, OnTapped: r.showPopup}
+	}
+
+	r.cal = NewDateRangeCalendar(time.Time{}, time.Time{}, true, func(from, to time.Time) {
+		r.SetRange(from, to)
+		if r.popup != nil {
+			r.popup.Hide()
+		}
+	})
+	return r
+}
+
+func (r *DateRangeEntry) CreateRenderer() fyne.WidgetRenderer {
+	dash := &widget.Label{Text: "–", Alignment: fyne.TextAlignCenter}
+	return widget.NewSimpleRenderer(container.New(&dateRangeLayout{}, r.from, dash, r.to))
+}
+
+// GetRange returns the period (zero times for empty dates).
+func (r *DateRangeEntry) GetRange() (from, to time.Time) {
+	return r.from.GetTime(), r.to.GetTime()
+}
+
+// SetRange sets the period, and calls OnChanged once if it changed.
+func (r *DateRangeEntry) SetRange(from, to time.Time) {
+	r.updating = true
+	r.from.SetTime(from)
+	r.to.SetTime(to)
+	r.updating = false
+	r.changed()
+}
+
+// GetText returns the period as "from - to" (see DateEntry.GetText), or "" if both dates are empty.
+func (r *DateRangeEntry) GetText() string {
+	from, to := r.from.GetText(), r.to.GetText()
+	if from == "" && to == "" {
+		return ""
+	}
+	return from + " - " + to
+}
+
+// SetText sets the period from a "from - to" text (see DateEntry.SetText).
+func (r *DateRangeEntry) SetText(s string) {
+	from, to, _ := strings.Cut(s, " - ")
+	r.updating = true
+	r.from.SetText(strings.TrimSpace(from))
+	r.to.SetText(strings.TrimSpace(to))
+	r.updating = false
+	r.changed()
+}
+
+// From returns the entry of the start date.
+func (r *DateRangeEntry) From() *DateEntry { return r.from }
+
+// To returns the entry of the end date.
+func (r *DateRangeEntry) To() *DateEntry { return r.to }
+
+// SetFormat changes the date format of both entries (see DateEntry.SetFormat).
+func (r *DateRangeEntry) SetFormat(f *DateFormat) {
+	r.from.SetFormat(f)
+	r.to.SetFormat(f)
+}
+
+// Validate returns an error if a date is invalid (see DateEntry.Validate), or if the end date is before the start date.
+func (r *DateRangeEntry) Validate() error {
+	r.syncConstraints()
+	if err := r.from.Validate(); err != nil {
+		return err
+	}
+	if err := r.to.Validate(); err != nil {
+		return err
+	}
+	if from, to := r.GetRange(); !from.IsZero() && !to.IsZero() && to.Before(from) {
+		return errors.New(lang.L("The end date is before the start date"))
+	}
+	return nil
+}
+
+// SetOnValidationChanged is intended for parent widgets or containers to hook into the validation.
+func (r *DateRangeEntry) SetOnValidationChanged(callback func(error)) {
+	r.onValidationChanged = callback
+}
+
+// ----------------------------------------------------------------------------
+// Disableable / ReadOnlyable
+
+func (r *DateRangeEntry) Enable() {
+	r.DisableableWidget.Enable()
+	r.from.Enable()
+	r.to.Enable()
+}
+
+func (r *DateRangeEntry) Disable() {
+	r.DisableableWidget.Disable()
+	r.from.Disable()
+	r.to.Disable()
+}
+
+// ReadOnly returns read-only status.
+func (r *DateRangeEntry) ReadOnly() bool { return r.readOnly }
+
+// SetReadOnly sets read-only status of both entries.
+func (r *DateRangeEntry) SetReadOnly(b bool) {
+	r.readOnly = b
+	r.from.SetReadOnly(b)
+	r.to.SetReadOnly(b)
+	if b && r.popup != nil {
+		r.popup.Hide()
+	}
+}
+
+// ----------------------------------------------------------------------------
+// internals
+
+func (r *DateRangeEntry) syncConstraints() {
+	for _, d := range []*DateEntry{r.from, r.to} {
+		d.MinDate, d.MaxDate, d.DisabledDate = r.MinDate, r.MaxDate, r.DisabledDate
+	}
+	r.cal.MinDate, r.cal.MaxDate, r.cal.DisabledDate = r.MinDate, r.MaxDate, r.DisabledDate
+}
+
+func (r *DateRangeEntry) showPopup() {
+	if r.readOnly || r.Disabled() {
+		return
+	}
+	if r.popup == nil {
+		r.popup = widget.NewPopUp(r.cal, fyne.CurrentApp().Driver().CanvasForObject(r))
+	}
+	pos := fyne.CurrentApp().Driver().AbsolutePositionForObject(r)
+	pos.Y += r.Size().Height + theme.Padding()
+	r.popup.ShowAtPosition(pos)
+
+	// set range after show, because the calendars widgets are created in CreateRenderer
+	r.syncConstraints()
+	from, to := r.GetRange()
+	r.cal.SetRange(from, to)
+	if from.IsZero() {
+		r.cal.SetDisplayedDate(time.Now())
+	}
+}
+
+func (r *DateRangeEntry) validityChanged() {
+	if invalid := r.Validate() != nil; invalid != r.invalid {
+		r.invalid = invalid
+		if r.onValidationChanged != nil {
+			r.onValidationChanged(r.Validate())
+		}
+	}
+}
+
+func (r *DateRangeEntry) changed() {
+	if r.updating {
+		return
+	}
+	r.validityChanged()
+
+	from, to := r.GetRange()
+	if from.Equal(r.lastFrom) && to.Equal(r.lastTo) {
+		return
+	}
+	r.lastFrom, r.lastTo = from, to
+	if r.OnChanged != nil {
+		r.OnChanged(from, to)
+	}
+}
+
+// dateRangeLayout gives the same width to both entries, with the dash in between.
+type dateRangeLayout struct{}
+
+func (l *dateRangeLayout) Layout(objects []fyne.CanvasObject, size fyne.Size) {
+	dash := objects[1].MinSize().Width
+	w := (size.Width - dash) / 2
+	objects[0].Move(fyne.NewPos(0, 0))
+	objects[0].Resize(fyne.NewSize(w, size.Height))
+	objects[1].Move(fyne.NewPos(w, 0))
+	objects[1].Resize(fyne.NewSize(dash, size.Height))
+	objects[2].Move(fyne.NewPos(w+dash, 0))
+	objects[2].Resize(fyne.NewSize(size.Width-w-dash, size.Height))
+}
+
+func (l *dateRangeLayout) MinSize(objects []fyne.CanvasObject) fyne.Size {
+	from, dash, to := objects[0].MinSize(), objects[1].MinSize(), objects[2].MinSize()
+	w := fyne.Max(from.Width, to.Width)
+	return fyne.NewSize(2*w+dash.Width, fyne.Max(from.Height, fyne.Max(dash.Height, to.Height)))
+}
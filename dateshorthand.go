@@ -0,0 +1,131 @@
+package wx
+
+import (
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"fyne.io/fyne/v2/lang"
+)
+
+// DateShorthandWords are the words accepted by DateEntry as dates, with their offset in days from today
+// (lower case). Applications can add their own words (abbreviations...).
+//
+// The translations of "Today", "Tomorrow", "Yesterday", "After tomorrow" and "Before yesterday" (see lang.L)
+// are also accepted.
+var DateShorthandWords = map[string]int{"t": 0}
+
+// dateShorthandKeys are the translated words of parseDateShorthand, with their offset in days from today.
+var dateShorthandKeys = map[string]int{
+	"Today": 0, "Tomorrow": 1, "Yesterday": -1, "After tomorrow": 2, "Before yesterday": -2,
+}
+
+// parseDateShorthand evaluates a date shorthand (see DateEntry):
+//
+//	t, today...          a word of DateShorthandWords, or a translated word (see dateShorthandKeys)
+//	+7, -3m, +2w, +1y    offset from today, in days (default, or d/j), weeks (w/s), months (m) or years (y/a)
+//	15, 15/3, 15/3/24    day of the current month, day and month of the current year (in the order of f),
+//	                     full date with a two-digit year (see completeDate), any non-digit separates the fields
+func parseDateShorthand(s string, f DateFormat, now time.Time, pivot int) (time.Time, bool) {
+	s = strings.ToLower(strings.TrimSpace(s))
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.Local)
+
+	if days, ok := DateShorthandWords[s]; ok {
+		return today.AddDate(0, 0, days), true
+	}
+	for word, days := range dateShorthandKeys {
+		if s == strings.ToLower(lang.L(word)) {
+			return today.AddDate(0, 0, days), true
+		}
+	}
+
+	if strings.HasPrefix(s, "+") || strings.HasPrefix(s, "-") {
+		num, unit := s[1:], byte('d')
+		if n := len(num); n > 0 && (num[n-1] < '0' || num[n-1] > '9') {
+			num, unit = num[:n-1], num[n-1]
+		}
+		n, err := strconv.Atoi(num)
+		if err != nil || num == "" || num[0] < '0' || num[0] > '9' {
+			return time.Time{}, false
+		}
+		if s[0] == '-' {
+			n = -n
+		}
+		switch unit {
+		case 'd', 'j':
+			return today.AddDate(0, 0, n), true
+		case 'w', 's':
+			return today.AddDate(0, 0, 7*n), true
+		case 'm':
+			return today.AddDate(0, n, 0), true
+		case 'y', 'a':
+			return today.AddDate(n, 0, 0), true
+		}
+		return time.Time{}, false
+	}
+
+	for _, r := range s {
+		if unicode.IsLetter(r) {
+			return time.Time{}, false
+		}
+	}
+	return dateFromParts(strings.FieldsFunc(s, func(r rune) bool { return r < '0' || r > '9' }), f, now, pivot)
+}
+
+// dateFromParts completes a date from its first fields (see parseDateShorthand).
+func dateFromParts(parts []string, f DateFormat, now time.Time, pivot int) (time.Time, bool) {
+	values := map[byte]string{}
+	switch len(parts) {
+	case 1:
+		values['d'] = parts[0]
+	case 2:
+		order := strings.ReplaceAll(f.fields(), "y", "")
+		values[order[0]], values[order[1]] = parts[0], parts[1]
+	case 3:
+		order := f.fields()
+		values[order[0]], values[order[1]], values[order[2]] = parts[0], parts[1], parts[2]
+	default:
+		return time.Time{}, false
+	}
+	return completeDate(values['d'], values['m'], values['y'], now, pivot)
+}
+
+// completeDate returns the date of the digits of its fields, the current month and year being used
+// for empty fields. Two-digit years below pivot are in the 2000s, the others in the 1900s.
+func completeDate(day, month, year string, now time.Time, pivot int) (time.Time, bool) {
+	if day == "" || len(day) > 2 || len(month) > 2 {
+		return time.Time{}, false
+	}
+	d, err := strconv.Atoi(day)
+	if err != nil {
+		return time.Time{}, false
+	}
+	m := int(now.Month())
+	if month != "" {
+		if m, err = strconv.Atoi(month); err != nil {
+			return time.Time{}, false
+		}
+	}
+	y := now.Year()
+	switch len(year) {
+	case 0:
+	case 2, 4:
+		if y, err = strconv.Atoi(year); err != nil {
+			return time.Time{}, false
+		}
+		if len(year) == 2 && y < pivot {
+			y += 2000
+		} else if len(year) == 2 {
+			y += 1900
+		}
+	default:
+		return time.Time{}, false
+	}
+
+	tm := time.Date(y, time.Month(m), d, 0, 0, 0, 0, time.Local)
+	if tm.Day() != d || int(tm.Month()) != m || tm.Year() != y {
+		return time.Time{}, false // e.g. 31/02
+	}
+	return tm, true
+}
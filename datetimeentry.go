@@ -0,0 +1,304 @@
+package wx
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/lang"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+)
+
+// DateTimeEntry is a date and time input: a DateEntry and a TimeEntry sharing a popup
+// with the Calendar and hour/minute selectors.
+//
+// The date and time are those of Location: GetTime returns an instant in Location,
+// and SetTime displays any instant converted to Location.
+type DateTimeEntry struct {
+	widget.DisableableWidget
+
+	Location *time.Location // time zone of the displayed date and time (time.Local if nil, see SetLocation)
+
+	OnChanged func(time.Time)
+
+	// custom callbacks, forwarded from the entries
+	OnTypedKey      func(*fyne.KeyEvent) (block bool)
+	OnTypedShortcut func(fyne.Shortcut) (block bool)
+
+	date *DateEntry
+	time *TimeEntry
+
+	popup   *widget.PopUp
+	cal     *Calendar
+	hour    *NumEntry
+	minute  *NumEntry
+	zone    *widget.Label
+	syncing bool
+
+	lastValidTime       time.Time
+	updating            bool
+	readOnly            bool
+	invalid             bool
+	onValidationChanged func(error)
+}
+
+func NewDateTimeEntry() *DateTimeEntry {
+	e := &DateTimeEntry{date: NewDateEntry(), time: NewTimeEntry()}
+	e.ExtendBaseWidget(e)
+
+	e.date.OnChanged = func(_ time.Time) { e.changed() }
+	e.time.OnChanged = func(_ time.Time) { e.changed() }
+	e.date.ActionItem = &widget.Button{Icon: theme.CalendarIcon(), Importance: widget.LowImportance, OnTapped: e.showPopup}
+	for _, w := range []interface {
+		SetOnValidationChanged(func(error))
+	}{e.date, e.time} {
+		w.SetOnValidationChanged(func(_ error) { e.validityChanged() })
+	}
+	e.date.OnTypedKey = e.typedKey
+	e.time.OnTypedKey = e.typedKey
+	e.date.OnTypedShortcut = e.typedShortcut
+	e.time.OnTypedShortcut = e.typedShortcut
+
+	e.cal = NewCalendar(time.Now(), time.Time{}, func(tm time.Time) {
+		e.date.SetTime(tm)
+		e.fillTime()
+	})
+
+	fnSelector := func(max float64) *NumEntry {
+		n := NewNumEntry()
+		n.Max, n.Wrap, n.Spinner = max, true, true
+		n.SetMinColsVisible(2)
+		n.OnChangedInt = func(_ int) { e.selectorChanged() }
+		return n
+	}
+	e.hour = fnSelector(23)
+	e.minute = fnSelector(59)
+	e.zone = &widget.Label{Alignment: fyne.TextAlignTrailing, Importance: widget.LowImportance}
+	return e
+}
+
+func (e *DateTimeEntry) CreateRenderer() fyne.WidgetRenderer {
+	return widget.NewSimpleRenderer(container.NewBorder(nil, nil, nil, e.time, e.date))
+}
+
+// GetTime returns the date and time in Location (midnight if the time is empty),
+// the zero time if the date is empty.
+func (e *DateTimeEntry) GetTime() time.Time {
+	d := e.date.GetTime()
+	if d.IsZero() {
+		return time.Time{}
+	}
+	t := e.time.GetTime()
+	return time.Date(d.Year(), d.Month(), d.Day(), t.Hour(), t.Minute(), t.Second(), 0, e.location())
+}
+
+// SetTime displays tm converted to Location (the zero time clears both entries), and calls OnChanged once if it changed.
+func (e *DateTimeEntry) SetTime(tm time.Time) {
+	if !tm.IsZero() {
+		tm = tm.In(e.location())
+	}
+	e.updating = true
+	e.date.SetTime(tm)
+	e.time.SetTime(tm)
+	e.updating = false
+	e.changed()
+}
+
+// GetText returns the date and time as "date time" (see DateEntry.GetText and TimeEntry.GetText),
+// or "" if the date is empty.
+func (e *DateTimeEntry) GetText() string {
+	date := e.date.GetText()
+	if date == "" {
+		return ""
+	}
+	if t := e.time.GetText(); t != "" {
+		return date + " " + t
+	}
+	return date
+}
+
+// SetText sets the date and time from a "date time" text (see DateEntry.SetText and TimeEntry.SetText).
+func (e *DateTimeEntry) SetText(s string) {
+	date, t, _ := strings.Cut(strings.TrimSpace(s), " ")
+	e.updating = true
+	e.date.SetText(date)
+	e.time.SetText(t)
+	e.updating = false
+	e.changed()
+}
+
+// SetLocation changes the time zone (nil for time.Local), the displayed date and time are converted
+// so that GetTime returns the same instant.
+func (e *DateTimeEntry) SetLocation(loc *time.Location) {
+	tm := e.GetTime()
+	e.Location = loc
+	e.SetTime(tm)
+}
+
+// Date returns the entry of the date.
+func (e *DateTimeEntry) Date() *DateEntry { return e.date }
+
+// Time returns the entry of the time.
+func (e *DateTimeEntry) Time() *TimeEntry { return e.time }
+
+// SetDateFormat changes the date format (see DateEntry.SetFormat).
+func (e *DateTimeEntry) SetDateFormat(f *DateFormat) { e.date.SetFormat(f) }
+
+// SetTimeFormat changes the time format (see TimeEntry.SetFormat).
+func (e *DateTimeEntry) SetTimeFormat(f *TimeFormat) { e.time.SetFormat(f) }
+
+// Validate returns an error if the date or the time is invalid, or if a time is typed without date.
+func (e *DateTimeEntry) Validate() error {
+	if err := e.date.Validate(); err != nil {
+		return err
+	}
+	if err := e.time.Validate(); err != nil {
+		return err
+	}
+	if e.date.GetText() == "" && e.time.GetText() != "" {
+		return errors.New(lang.L("The date is missing"))
+	}
+	return nil
+}
+
+// SetOnValidationChanged is intended for parent widgets or containers to hook into the validation.
+func (e *DateTimeEntry) SetOnValidationChanged(callback func(error)) {
+	e.onValidationChanged = callback
+}
+
+// ----------------------------------------------------------------------------
+// Disableable / ReadOnlyable
+
+func (e *DateTimeEntry) Enable() {
+	e.DisableableWidget.Enable()
+	e.date.Enable()
+	e.time.Enable()
+}
+
+func (e *DateTimeEntry) Disable() {
+	e.DisableableWidget.Disable()
+	e.date.Disable()
+	e.time.Disable()
+}
+
+// ReadOnly returns read-only status.
+func (e *DateTimeEntry) ReadOnly() bool { return e.readOnly }
+
+// SetReadOnly sets read-only status of both entries.
+func (e *DateTimeEntry) SetReadOnly(b bool) {
+	e.readOnly = b
+	e.date.SetReadOnly(b)
+	e.time.SetReadOnly(b)
+	if b && e.popup != nil {
+		e.popup.Hide()
+	}
+}
+
+// ----------------------------------------------------------------------------
+// internals
+
+func (e *DateTimeEntry) location() *time.Location {
+	if e.Location != nil {
+		return e.Location
+	}
+	return time.Local
+}
+
+func (e *DateTimeEntry) typedKey(ke *fyne.KeyEvent) bool {
+	return e.OnTypedKey != nil && e.OnTypedKey(ke) || e.cal.forwardKey(e.popup, ke)
+}
+func (e *DateTimeEntry) typedShortcut(s fyne.Shortcut) bool {
+	return e.OnTypedShortcut != nil && e.OnTypedShortcut(s) || e.cal.forwardShortcut(e.popup, s)
+}
+
+func (e *DateTimeEntry) showPopup() {
+	if e.readOnly || e.Disabled() {
+		return
+	}
+	if e.popup == nil {
+		sep := widget.NewLabel(":")
+		e.popup = widget.NewPopUp(container.NewVBox(
+			e.cal,
+			container.NewBorder(nil, nil, container.NewHBox(widget.NewIcon(theme.HistoryIcon()), e.hour, sep, e.minute), nil, e.zone),
+		), fyne.CurrentApp().Driver().CanvasForObject(e))
+	}
+	pos := fyne.CurrentApp().Driver().AbsolutePositionForObject(e)
+	pos.Y += e.Size().Height + theme.Padding()
+	e.popup.ShowAtPosition(pos)
+
+	// set values after show, because the calendar widgets are created in CreateRenderer
+	e.syncPopup()
+}
+
+// syncPopup shows the value of the entries in the popup.
+func (e *DateTimeEntry) syncPopup() {
+	if e.popup == nil {
+		return
+	}
+	e.syncing = true
+	defer func() { e.syncing = false }()
+
+	d := e.date.GetTime()
+	e.cal.MinDate, e.cal.MaxDate, e.cal.DisabledDate = e.date.MinDate, e.date.MaxDate, e.date.DisabledDate
+	oldCB := e.cal.OnChanged
+	e.cal.OnChanged = nil
+	e.cal.SetSelectedDate(d)
+	e.cal.SetDisplayedDate(d)
+	e.cal.OnChanged = oldCB
+
+	t := e.time.GetTime()
+	e.hour.SetInt(t.Hour())
+	e.minute.SetInt(t.Minute())
+
+	name, _ := time.Now().In(e.location()).Zone()
+	if e.location() != time.Local && e.location().String() != name {
+		name = e.location().String() + " (" + name + ")"
+	}
+	e.zone.SetText(name)
+}
+
+// fillTime sets midnight when a date is picked without time.
+func (e *DateTimeEntry) fillTime() {
+	if e.time.GetText() == "" {
+		e.time.SetTime(time.Date(0, 1, 1, e.hour.GetInt(), e.minute.GetInt(), 0, 0, time.UTC))
+	}
+}
+
+func (e *DateTimeEntry) selectorChanged() {
+	if e.syncing {
+		return
+	}
+	t := e.time.GetTime()
+	e.time.SetTime(time.Date(0, 1, 1, e.hour.GetInt(), e.minute.GetInt(), t.Second(), 0, time.UTC))
+}
+
+func (e *DateTimeEntry) validityChanged() {
+	if invalid := e.Validate() != nil; invalid != e.invalid {
+		e.invalid = invalid
+		if e.onValidationChanged != nil {
+			e.onValidationChanged(e.Validate())
+		}
+	}
+}
+
+func (e *DateTimeEntry) changed() {
+	if e.updating {
+		return
+	}
+	e.validityChanged()
+	if e.popup != nil && e.popup.Visible() && !e.syncing {
+		e.syncPopup()
+	}
+
+	tm := e.GetTime()
+	if tm.Equal(e.lastValidTime) {
+		return
+	}
+	e.lastValidTime = tm
+	if e.OnChanged != nil {
+		e.OnChanged(tm)
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"strings"
 
 	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/data/binding"
 	"fyne.io/fyne/v2/driver/desktop"
 	"fyne.io/fyne/v2/lang"
 	"fyne.io/fyne/v2/theme"
@@ -43,10 +44,13 @@ type EntryEx struct {
 
 	readOnly bool
 	minCols  int
+	minRows  int
+
+	binder dataBinder
 }
 
 func NewEntryEx(minRows int) *EntryEx {
-	e := &EntryEx{}
+	e := &EntryEx{minRows: minRows}
 	e.ExtendBaseWidget(e)
 	e.ToolTipable.parent = e
 	e.Wrapping = fyne.TextTruncate
@@ -74,6 +78,24 @@ func (e *EntryEx) onChanged(s string) {
 	if e.OnChanged != nil {
 		e.OnChanged(s)
 	}
+	e.binder.toData(func() { e.binder.data.(binding.String).Set(s) })
+}
+
+// Bind connects the entry to a string data source.
+// Changes of the data update the entry, and typed text is written to the data
+// (after CaseModifier is applied).
+func (e *EntryEx) Bind(data binding.String) {
+	e.binder.bind(data, func() {
+		if s, err := data.Get(); err == nil && s != e.Text {
+			e.SetText(s)
+		}
+	})
+}
+
+// Unbind disconnects the data source set by Bind.
+// The entry keeps its current value.
+func (e *EntryEx) Unbind() {
+	e.binder.unbind()
 }
 
 func (e *EntryEx) AcceptsTab() bool {
@@ -1,15 +1,18 @@
 package wx
 
 import (
+	"encoding/json"
 	"fmt"
+	"math/big"
 	"reflect"
 	"strconv"
 	"strings"
 	"time"
 
 	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
 	"fyne.io/fyne/v2/container"
-	"fyne.io/fyne/v2/layout"
+	"fyne.io/fyne/v2/theme"
 	"fyne.io/fyne/v2/widget"
 )
 
@@ -20,7 +23,31 @@ type FieldID = string
 type inputField struct {
 	fyne.Widget
 	label string
-	check *widget.Check
+	check *Check
+
+	readOnly bool
+
+	labelWidget *widget.Label
+	labelCell   fyne.CanvasObject
+	cell        *fyne.Container
+
+	// validation
+	validators []Validator
+	err        error
+	errBorder  *canvas.Rectangle
+	errLabel   *widget.Label
+
+	dirty bool
+
+	// rules
+	form       *fyne.Container
+	hidden     bool
+	showWhen   func(w *InputFields) bool
+	enableWhen func(w *InputFields) bool
+
+	binder dataBinder
+
+	span int // number of column pairs (see SetFieldSpan)
 }
 
 type InputFields struct {
@@ -33,26 +60,73 @@ type InputFields struct {
 	OnChanged func(id FieldID)
 	OnAction  func(id FieldID)
 
+	OnValidationChanged func(valid bool)
+	OnDirtyChanged      func(dirty bool)
+
+	DirtyMarker bool // appends " *" to the labels of modified inputs (see MarkClean)
+
 	OnTypedKey      func(ke *fyne.KeyEvent) (handled bool)
 	OnTypedShortcut func(s fyne.Shortcut) (handled bool)
 
+	EnterNavigation bool   // Enter moves the focus to the next input (Shift+Enter to the previous one)
+	OnSubmit        func() // called when Enter is pressed in the last input (see EnterNavigation)
+
+	ResponsiveWidth float32 // multi-column forms (see SetColumns) drop to one column under this width (0 to disable)
+
 	inputs map[FieldID]*inputField
 	order  []FieldID
+	cells  map[fyne.CanvasObject]*inputField // by cell
+
+	vbox    *fyne.Container
+	tabs    *container.AppTabs
+	content *fyne.Container // renderer content: vbox or tabs
+	section *fyne.Container // current section content (see AddSection)
+	columns int             // column pairs of the new forms (see SetColumns)
+
+	columnsChanged bool // a form changed its number of columns while laid out (see Resize)
+
+	valid bool
+
+	clean map[FieldID]any
+	dirty bool
+
+	applyingRules bool
+
+	readOnly bool
 
-	vbox *fyne.Container
-	tabs *container.AppTabs
+	markdowns map[*widget.RichText]string
+
+	// history
+	values     map[FieldID]any // last known values
+	history    []historyEntry
+	historyPos int
+	lastEdit   time.Time
+	undoing    bool
 }
 
 func (w *InputFields) typedKey(ke *fyne.KeyEvent) (handled bool) {
 	if w.OnTypedKey != nil {
 		handled = w.OnTypedKey(ke)
 	}
+	if !handled && w.EnterNavigation && (ke.Name == fyne.KeyReturn || ke.Name == fyne.KeyEnter) {
+		handled = w.navigate(shiftPressed())
+	}
 	return
 }
 func (w *InputFields) typedShortcut(s fyne.Shortcut) (handled bool) {
 	if w.OnTypedShortcut != nil {
 		handled = w.OnTypedShortcut(s)
 	}
+	if !handled && !w.readOnly {
+		switch s.(type) {
+		case *fyne.ShortcutUndo:
+			w.Undo()
+			handled = true
+		case *fyne.ShortcutRedo:
+			w.Redo()
+			handled = true
+		}
+	}
 	return
 }
 
@@ -60,18 +134,23 @@ func (w *InputFields) typedShortcut(s fyne.Shortcut) (handled bool) {
 // Creation
 
 func NewInputFields(win fyne.Window) (w *InputFields) {
-	w = &InputFields{win: win, inputs: make(map[FieldID]*inputField)}
+	w = &InputFields{win: win, inputs: make(map[FieldID]*inputField), cells: make(map[fyne.CanvasObject]*inputField), values: make(map[FieldID]any), valid: true}
 	w.ExtendBaseWidget(w)
 	return w
 }
 
 func (w *InputFields) CreateRenderer() fyne.WidgetRenderer {
-	if w.vbox != nil {
-		return widget.NewSimpleRenderer(w.vbox)
-	} else if w.tabs != nil {
-		return widget.NewSimpleRenderer(w.tabs)
-	} else {
-		return widget.NewSimpleRenderer(widget.NewLabel("(!) ERREUR: Aucun champs d'entrée défini."))
+	w.content = container.NewStack()
+	w.updateContent()
+	return widget.NewSimpleRenderer(w.content)
+}
+
+// Resize refreshes the content if a form changed its number of columns (see ResponsiveWidth), as its height changed.
+func (w *InputFields) Resize(size fyne.Size) {
+	w.BaseWidget.Resize(size)
+	if w.columnsChanged && w.content != nil {
+		w.columnsChanged = false
+		w.content.Refresh()
 	}
 }
 
@@ -84,7 +163,9 @@ func (w *InputFields) AddTab(title string, icon fyne.Resource) {
 	}
 	if w.tabs == nil {
 		w.tabs = container.NewAppTabs()
+		w.updateContent()
 	}
+	w.section = nil
 	w.tabs.Append(container.NewTabItemWithIcon(title, icon, container.NewVBox()))
 }
 
@@ -97,7 +178,12 @@ func (w *InputFields) AddTitle(text string, style fyne.TextStyle, alignement fyn
 }
 
 func (w *InputFields) AddTitleMkd(text string) {
-	w.currentVBox().Add(widget.NewRichTextFromMarkdown(text))
+	rt := widget.NewRichTextFromMarkdown(text)
+	if w.markdowns == nil {
+		w.markdowns = make(map[*widget.RichText]string)
+	}
+	w.markdowns[rt] = text
+	w.currentVBox().Add(rt)
 }
 
 // ----------------------------------------------------------------------------
@@ -132,13 +218,39 @@ func (w *InputFields) AddPassword(id FieldID, nullable bool, label string, value
 	w.addWidget(id, nullable, label, wid)
 }
 
-func (w *InputFields) AddNumber(id FieldID, nullable bool, label string, value string, float, signed bool) {
+// NumberOptions are the optional settings of a number input (see AddNumber and NumEntry).
+type NumberOptions struct {
+	Min, Max float64 // no bounds if Min == Max
+	Reject   bool    // out of range values are rejected instead of being clamped
+	Decimals int     // fixed number of fraction digits (0 for any)
+
+	Decimal  bool         // exact decimal values (*big.Rat, see NumEntry.Decimal)
+	Rounding RoundingMode // rounding of decimal values to Decimals
+
+	Step    float64 // 1 if 0
+	Wrap    bool
+	Spinner bool
+
+	Calculator bool // arithmetic expressions are accepted (see NumEntry.Calculator)
+}
+
+// AddNumber adds a number input, opts (at most one) sets its bounds, precision and stepping.
+func (w *InputFields) AddNumber(id FieldID, nullable bool, label string, value string, float, signed bool, opts ...NumberOptions) {
 	w.dummyId(&id)
 	wid := NewNumEntry()
 	wid.Float = float
 	wid.Signed = signed
+	if len(opts) > 0 {
+		o := opts[0]
+		wid.Min, wid.Max, wid.Reject, wid.Decimals = o.Min, o.Max, o.Reject, o.Decimals
+		wid.Step, wid.Wrap, wid.Spinner = o.Step, o.Wrap, o.Spinner
+		wid.Decimal, wid.Rounding = o.Decimal, o.Rounding
+		wid.Calculator = o.Calculator
+	}
 	wid.SetText(value)
-	if !float {
+	if wid.Decimal {
+		wid.OnChangedDecimal = func(_ *big.Rat) { w.onChanged(id) }
+	} else if !float {
 		wid.OnChangedInt = func(_ int) { w.onChanged(id) }
 	} else {
 		wid.OnChangedFloat = func(_ float64) { w.onChanged(id) }
@@ -158,6 +270,42 @@ func (w *InputFields) AddDate(id FieldID, nullable bool, label string, value str
 	w.addWidget(id, nullable, label, wid)
 }
 
+// AddTime adds a time of day input (see TimeEntry), its value is a time.Time of the day 0000-01-01.
+func (w *InputFields) AddTime(id FieldID, nullable bool, label string, value string) {
+	w.dummyId(&id)
+	wid := NewTimeEntry()
+	wid.SetText(value)
+	wid.OnChanged = func(_ time.Time) { w.onChanged(id) }
+	wid.OnTypedKey = w.typedKey
+	wid.OnTypedShortcut = w.typedShortcut
+	w.addWidget(id, nullable, label, wid)
+}
+
+// AddDateTime adds a date and time input (see DateTimeEntry) in the time zone loc (time.Local if nil),
+// value is a "date time" text (e.g. "31/12/2024 23:59").
+func (w *InputFields) AddDateTime(id FieldID, nullable bool, label string, value string, loc *time.Location) {
+	w.dummyId(&id)
+	wid := NewDateTimeEntry()
+	wid.Location = loc
+	wid.SetText(value)
+	wid.OnChanged = func(_ time.Time) { w.onChanged(id) }
+	wid.OnTypedKey = w.typedKey
+	wid.OnTypedShortcut = w.typedShortcut
+	w.addWidget(id, nullable, label, wid)
+}
+
+// AddDateRange adds a period input (see DateRangeEntry), its value is a [2]time.Time{from, to}.
+func (w *InputFields) AddDateRange(id FieldID, nullable bool, label string, from, to string) {
+	w.dummyId(&id)
+	wid := NewDateRangeEntry()
+	wid.From().SetText(from)
+	wid.To().SetText(to)
+	wid.OnChanged = func(_, _ time.Time) { w.onChanged(id) }
+	wid.OnTypedKey = w.typedKey
+	wid.OnTypedShortcut = w.typedShortcut
+	w.addWidget(id, nullable, label, wid)
+}
+
 func (w *InputFields) AddSelect(id FieldID, nullable bool, label string, options []string, value string, editable bool) {
 	w.dummyId(&id)
 	if editable {
@@ -188,7 +336,7 @@ func (w *InputFields) AddCheck(id FieldID, nullable bool, label string, text str
 
 func (w *InputFields) AddCheckGroup(id FieldID, nullable bool, label string, options []string, values []string, horizontal bool) {
 	w.dummyId(&id)
-	wid := widget.NewCheckGroup(options, func(_ []string) { w.onChanged(id) })
+	wid := NewCheckGroup(options, func(_ []string) { w.onChanged(id) })
 	wid.Selected = values
 	wid.Horizontal = horizontal
 	w.addWidget(id, nullable, label, wid)
@@ -196,12 +344,34 @@ func (w *InputFields) AddCheckGroup(id FieldID, nullable bool, label string, opt
 
 func (w *InputFields) AddRadioGroup(id FieldID, nullable bool, label string, options []string, value string, horizontal bool) {
 	w.dummyId(&id)
-	wid := widget.NewRadioGroup(options, func(_ string) { w.onChanged(id) })
+	wid := NewRadioGroup(options, func(_ string) { w.onChanged(id) })
 	wid.Selected = value
 	wid.Horizontal = horizontal
 	w.addWidget(id, nullable, label, wid)
 }
 
+// AddMultiText adds an editable list of texts (see MultiEntry), options are used for auto-completion.
+func (w *InputFields) AddMultiText(id FieldID, nullable bool, label string, values []string, options []string) {
+	w.dummyId(&id)
+	wid := NewMultiEntry(values, func(_ []string) { w.onChanged(id) })
+	wid.Options = options
+	wid.OnTypedKey = w.typedKey
+	wid.OnTypedShortcut = w.typedShortcut
+	w.addWidget(id, nullable, label, wid)
+}
+
+// AddRepeater adds a list of sub-forms created by template, with add, remove and reorder buttons (see Repeater).
+//
+// Its value is a []map[string]any, one map per row (see ReadAll).
+func (w *InputFields) AddRepeater(id FieldID, nullable bool, label string, template func(sub *InputFields)) {
+	w.dummyId(&id)
+	wid := NewRepeater(w.win, template)
+	wid.OnChanged = func() { w.onChanged(id) }
+	wid.OnTypedKey = w.typedKey
+	wid.OnTypedShortcut = w.typedShortcut
+	w.addWidget(id, nullable, label, wid)
+}
+
 func (w *InputFields) AddActionButton(id FieldID, label, btnText string, importance widget.Importance) {
 	w.dummyId(&id)
 	w.addWidget(id, false, label, &widget.Button{
@@ -233,8 +403,17 @@ func (w *InputFields) Read(id FieldID) (ret any) {
 		ret = wid.Text
 	case *DateEntry:
 		ret = wid.GetTime()
+	case *TimeEntry:
+		ret = wid.GetTime()
+	case *DateTimeEntry:
+		ret = wid.GetTime()
+	case *DateRangeEntry:
+		from, to := wid.GetRange()
+		ret = [2]time.Time{from, to}
 	case *NumEntry:
-		if wid.Float {
+		if wid.Decimal {
+			ret = wid.GetDecimal()
+		} else if wid.Float {
 			ret = wid.GetFloat()
 		} else {
 			ret = wid.GetInt()
@@ -257,12 +436,20 @@ func (w *InputFields) Read(id FieldID) (ret any) {
 		} else {
 			ret = []string{}
 		}
-	case *widget.RadioGroup:
+	case *CheckGroup:
 		if len(wid.Selected) > 0 {
 			ret = wid.Selected
 		} else {
 			ret = []string{}
 		}
+	case *widget.RadioGroup:
+		ret = wid.Selected
+	case *RadioGroup:
+		ret = wid.Selected
+	case *MultiEntry:
+		ret = wid.Values()
+	case *Repeater:
+		ret = wid.GetValues()
 	case *widget.Button:
 		ret = wid.Text
 	}
@@ -301,14 +488,54 @@ func (w *InputFields) Write(id FieldID, value any) {
 		} else {
 			wid.SetText(fmt.Sprint(value))
 		}
+	case *TimeEntry:
+		if t, ok := value.(time.Time); ok {
+			wid.SetTime(t)
+		} else {
+			wid.SetText(fmt.Sprint(value))
+		}
+	case *DateTimeEntry:
+		if t, ok := value.(time.Time); ok {
+			wid.SetTime(t)
+		} else {
+			wid.SetText(fmt.Sprint(value))
+		}
+	case *DateRangeEntry:
+		switch v := value.(type) {
+		case [2]time.Time:
+			wid.SetRange(v[0], v[1])
+		case []time.Time:
+			if len(v) == 2 {
+				wid.SetRange(v[0], v[1])
+			}
+		case nil:
+			wid.SetRange(time.Time{}, time.Time{})
+		default:
+			wid.SetText(fmt.Sprint(value))
+		}
 	case *NumEntry:
-		switch value.(type) {
+		switch v := value.(type) {
+		case *big.Rat:
+			if v == nil {
+				wid.SetText("")
+			} else if wid.Decimal {
+				wid.SetDecimal(v)
+			} else {
+				f, _ := v.Float64()
+				if wid.Float {
+					wid.SetFloat(f)
+				} else {
+					wid.SetInt(int(f))
+				}
+			}
 		case int, int8, int16, int32, int64:
 			wid.SetInt(int(reflect.ValueOf(value).Int()))
 		case uint, uint8, uint16, uint32, uint64:
 			wid.SetInt(int(reflect.ValueOf(value).Uint()))
 		case float32, float64:
-			if wid.Float {
+			if wid.Decimal {
+				wid.SetDecimal(ratFromFloat(reflect.ValueOf(value).Float()))
+			} else if wid.Float {
 				wid.SetFloat(reflect.ValueOf(value).Float())
 			} else {
 				wid.SetInt(int(reflect.ValueOf(value).Float()))
@@ -375,9 +602,75 @@ func (w *InputFields) Write(id FieldID, value any) {
 	case *widget.RadioGroup:
 		if v, ok := value.(string); ok {
 			wid.SetSelected(v)
+		} else if v, ok := value.([]string); ok {
+			if len(v) <= 1 { // a single selection, or none
+				wid.SetSelected(strings.Join(v, ""))
+			}
+		} else {
+			wid.SetSelected(fmt.Sprint(value))
+		}
+	case *CheckGroup:
+		switch v := value.(type) {
+		case []string:
+			wid.SetSelected(v)
+		case []any:
+			var ok bool
+			s := make([]string, len(v))
+			for i := range v {
+				if s[i], ok = v[i].(string); !ok {
+					s[i] = fmt.Sprint(v[i])
+				}
+			}
+			wid.SetSelected(s)
+		default:
+			wid.SetSelected(strings.Split(fmt.Sprint(value), "|"))
+		}
+	case *RadioGroup:
+		if v, ok := value.(string); ok {
+			wid.SetSelected(v)
+		} else if v, ok := value.([]string); ok {
+			if len(v) <= 1 { // a single selection, or none
+				wid.SetSelected(strings.Join(v, ""))
+			}
 		} else {
 			wid.SetSelected(fmt.Sprint(value))
 		}
+	case *MultiEntry:
+		switch v := value.(type) {
+		case []string:
+			wid.SetValues(v)
+		case []any:
+			var ok bool
+			s := make([]string, len(v))
+			for i := range v {
+				if s[i], ok = v[i].(string); !ok {
+					s[i] = fmt.Sprint(v[i])
+				}
+			}
+			wid.SetValues(s)
+		default:
+			wid.SetValues(strings.Split(fmt.Sprint(value), "|"))
+		}
+	case *Repeater:
+		switch v := value.(type) {
+		case []map[string]any:
+			wid.SetValues(v)
+		case []any: // e.g. from JSON
+			rows := make([]map[string]any, 0, len(v))
+			for i := range v {
+				if m, ok := v[i].(map[string]any); ok {
+					rows = append(rows, m)
+				}
+			}
+			wid.SetValues(rows)
+		case string:
+			var rows []map[string]any
+			if json.Unmarshal([]byte(v), &rows) == nil {
+				wid.SetValues(rows)
+			}
+		case nil:
+			wid.SetValues(nil)
+		}
 	case *widget.Button:
 		if v, ok := value.(string); ok {
 			wid.SetText(v)
@@ -389,10 +682,18 @@ func (w *InputFields) Write(id FieldID, value any) {
 
 //
 
+// ReadAll returns the values of all the visible inputs.
 func (w *InputFields) ReadAll() (data map[string]any) {
+	return w.readAll(false)
+}
+
+// readAll reads the inputs, including the hidden ones if hidden is true (see MarkClean).
+func (w *InputFields) readAll(hidden bool) (data map[string]any) {
 	data = make(map[string]any)
 	for _, id := range w.order {
-		data[id] = w.Read(id)
+		if hidden || !w.inputs[id].hidden {
+			data[id] = w.Read(id)
+		}
 	}
 	return
 }
@@ -423,22 +724,30 @@ func (w *InputFields) WriteOptions(id FieldID, options []string) {
 	case *widget.CheckGroup:
 		wid.Options = options
 		wid.Refresh()
+	case *CheckGroup:
+		wid.Options = options
+		wid.Refresh()
 	case *widget.RadioGroup:
 		wid.Options = options
 		wid.Refresh()
+	case *RadioGroup:
+		wid.Options = options
+		wid.Refresh()
 	}
 }
 
 //
 
-/*func (w *InputFields) ReadString(id FieldID) (ret string) {
+// ReadString returns the textual representation of an input value
+// (see ReadAllString). ok is false if the input is null.
+func (w *InputFields) ReadString(id FieldID) (ret string, ok bool, err error) {
 	f := w.inputs[id]
 	if f == nil {
-		return
+		return "", false, unknownField(id)
 	}
 
 	if f.check != nil && !f.check.Checked {
-		return ""
+		return "", false, nil
 	}
 
 	switch wid := f.Widget.(type) {
@@ -450,8 +759,14 @@ func (w *InputFields) WriteOptions(id FieldID, options []string) {
 		ret = wid.Text
 	case *DateEntry:
 		ret = wid.GetText()
+	case *TimeEntry:
+		ret = wid.GetText()
+	case *DateTimeEntry:
+		ret = wid.GetText()
+	case *DateRangeEntry:
+		ret = wid.GetText()
 	case *NumEntry:
-		ret = wid.Text
+		ret = wid.GetText()
 	case *widget.Select:
 		ret = wid.Selected
 	case *Select:
@@ -466,14 +781,30 @@ func (w *InputFields) WriteOptions(id FieldID, options []string) {
 		ret = strconv.FormatBool(wid.Checked)
 	case *widget.CheckGroup:
 		ret = strings.Join(wid.Selected, "|")
+	case *CheckGroup:
+		ret = strings.Join(wid.Selected, "|")
 	case *widget.RadioGroup:
 		ret = wid.Selected
+	case *RadioGroup:
+		ret = wid.Selected
+	case *MultiEntry:
+		ret = strings.Join(wid.Values(), "|")
+	case *Repeater:
+		b, err := json.Marshal(wid.GetValues())
+		if err != nil {
+			return "", false, fmt.Errorf("wx: field %q: %w", id, err)
+		}
+		ret = string(b)
 	case *widget.Button:
 		ret = wid.Text
+	default:
+		return "", false, fmt.Errorf("wx: field %q: unsupported widget %T", id, f.Widget)
 	}
-	return
+	return ret, true, nil
 }
 
+// WriteString writes the textual representation of an input value (see ReadString),
+// without triggering OnChanged.
 func (w *InputFields) WriteString(id FieldID, value string) {
 	f := w.inputs[id]
 	if f == nil {
@@ -493,6 +824,12 @@ func (w *InputFields) WriteString(id FieldID, value string) {
 		wid.SetText(value)
 	case *DateEntry:
 		wid.SetText(value)
+	case *TimeEntry:
+		wid.SetText(value)
+	case *DateTimeEntry:
+		wid.SetText(value)
+	case *DateRangeEntry:
+		wid.SetText(value)
 	case *NumEntry:
 		wid.SetText(value)
 	case *widget.Select:
@@ -512,9 +849,17 @@ func (w *InputFields) WriteString(id FieldID, value string) {
 			wid.SetChecked(b)
 		}
 	case *widget.CheckGroup:
-		wid.SetSelected(strings.Split(value, "|"))
+		wid.SetSelected(splitStrings(value))
+	case *CheckGroup:
+		wid.SetSelected(splitStrings(value))
 	case *widget.RadioGroup:
 		wid.SetSelected(value)
+	case *RadioGroup:
+		wid.SetSelected(value)
+	case *MultiEntry:
+		wid.SetValues(splitStrings(value))
+	case *Repeater:
+		w.Write(id, value)
 	case *widget.Button:
 		wid.SetText(value)
 	}
@@ -522,19 +867,147 @@ func (w *InputFields) WriteString(id FieldID, value string) {
 
 //
 
+// ReadAllString returns the textual representation of all the visible inputs (null inputs are empty strings).
 func (w *InputFields) ReadAllString() (ret map[FieldID]string) {
 	ret = make(map[FieldID]string)
 	for _, id := range w.order {
-		ret[id] = w.ReadString(id)
+		if !w.inputs[id].hidden {
+			ret[id], _, _ = w.ReadString(id)
+		}
 	}
 	return
 }
 
+// WriteAllString writes the textual representation of several inputs, without triggering OnChanged.
 func (w *InputFields) WriteAllString(data map[FieldID]string) {
 	for k, v := range data {
 		w.WriteString(k, v)
 	}
-}*/
+}
+
+//
+
+// ReadInt returns the value of a number input (or parses the textual value of other inputs).
+// ok is false if the input is null.
+func (w *InputFields) ReadInt(id FieldID) (ret int, ok bool, err error) {
+	if wid, isNum := w.Widget(id).(*NumEntry); isNum {
+		if ok = !w.GetNull(id); ok {
+			ret = wid.GetInt()
+		}
+		return
+	}
+
+	s, ok, err := w.ReadString(id)
+	if !ok || err != nil {
+		return
+	}
+	if ret, err = strconv.Atoi(s); err != nil {
+		err = fmt.Errorf("wx: field %q: %w", id, err)
+	}
+	return
+}
+
+// ReadDecimal returns the exact value of a number input (or parses the textual value of other inputs).
+// ok is false if the input is null.
+func (w *InputFields) ReadDecimal(id FieldID) (ret *big.Rat, ok bool, err error) {
+	if wid, isNum := w.Widget(id).(*NumEntry); isNum {
+		if ok = !w.GetNull(id); ok {
+			ret = wid.GetDecimal()
+		}
+		return
+	}
+
+	s, ok, err := w.ReadString(id)
+	if !ok || err != nil {
+		return
+	}
+	var valid bool
+	if ret, valid = new(big.Rat).SetString(strings.Replace(s, ",", ".", 1)); !valid {
+		err = fmt.Errorf("wx: field %q: invalid decimal %q", id, s)
+	}
+	return
+}
+
+// ReadFloat returns the value of a number input (or parses the textual value of other inputs).
+// ok is false if the input is null.
+func (w *InputFields) ReadFloat(id FieldID) (ret float64, ok bool, err error) {
+	if wid, isNum := w.Widget(id).(*NumEntry); isNum {
+		if ok = !w.GetNull(id); ok {
+			ret = wid.GetFloat()
+		}
+		return
+	}
+
+	s, ok, err := w.ReadString(id)
+	if !ok || err != nil {
+		return
+	}
+	if ret, err = strconv.ParseFloat(strings.Replace(s, ",", ".", 1), 64); err != nil {
+		err = fmt.Errorf("wx: field %q: %w", id, err)
+	}
+	return
+}
+
+// ReadTime returns the value of a date input. ok is false if the input is null.
+func (w *InputFields) ReadTime(id FieldID) (ret time.Time, ok bool, err error) {
+	v, ok, err := w.read(id)
+	if !ok || err != nil {
+		return
+	}
+	if ret, ok = v.(time.Time); !ok {
+		err = fmt.Errorf("wx: field %q: %T is not a date", id, v)
+	}
+	return
+}
+
+// ReadBool returns the value of a check input (or parses the textual value of other inputs).
+// ok is false if the input is null.
+func (w *InputFields) ReadBool(id FieldID) (ret bool, ok bool, err error) {
+	s, ok, err := w.ReadString(id)
+	if !ok || err != nil {
+		return
+	}
+	if ret, err = strconv.ParseBool(s); err != nil {
+		err = fmt.Errorf("wx: field %q: %w", id, err)
+	}
+	return
+}
+
+// ReadStrings returns the selected options of a check group, the texts of a multi text (or the textual value of
+// other inputs as a list of zero or one item). ok is false if the input is null.
+func (w *InputFields) ReadStrings(id FieldID) (ret []string, ok bool, err error) {
+	v, ok, err := w.read(id)
+	if !ok || err != nil {
+		return
+	}
+	if s, isList := v.([]string); isList {
+		return append([]string{}, s...), true, nil
+	}
+
+	s, ok, err := w.ReadString(id)
+	if ok && err == nil {
+		ret = splitStrings(s)
+	}
+	return
+}
+
+// WriteInt writes the value of an input.
+func (w *InputFields) WriteInt(id FieldID, value int) { w.Write(id, value) }
+
+// WriteFloat writes the value of an input.
+func (w *InputFields) WriteFloat(id FieldID, value float64) { w.Write(id, value) }
+
+// WriteDecimal writes the exact value of an input.
+func (w *InputFields) WriteDecimal(id FieldID, value *big.Rat) { w.Write(id, value) }
+
+// WriteTime writes the value of an input.
+func (w *InputFields) WriteTime(id FieldID, value time.Time) { w.Write(id, value) }
+
+// WriteBool writes the value of an input.
+func (w *InputFields) WriteBool(id FieldID, value bool) { w.Write(id, value) }
+
+// WriteStrings writes the value of an input.
+func (w *InputFields) WriteStrings(id FieldID, value []string) { w.Write(id, value) }
 
 // ----------------------------------------------------------------------------
 // Manipuler les inputs
@@ -582,7 +1055,9 @@ func (w *InputFields) SetStatus(id FieldID, b bool) {
 					dis.Enable()
 				} else {
 					f.check.Enable()
-					f.check.SetChecked(f.check.Checked) // will update widget
+					if f.check.Checked {
+						dis.Enable()
+					}
 				}
 			} else {
 				dis.Disable()
@@ -606,6 +1081,7 @@ func (w *InputFields) GetStatus(id FieldID) (b bool) {
 func (w *InputFields) SetFocus(id FieldID) {
 	if f, ok := w.inputs[id]; ok {
 		if foc, ok := f.Widget.(fyne.Focusable); ok {
+			w.selectTabOf(f)
 			w.win.Canvas().Focus(foc)
 
 			/*if app := fyne.CurrentApp(); app != nil {
@@ -656,14 +1132,67 @@ func (w *InputFields) Disabled() (ret bool) {
 	return
 }
 
-// TODO wx.Readonlyable ? (pour afficher un document avec DocType.Inputs en readonly (autre user))
+// ----------------------------------------------------------------------------
+// ReadOnlyable
+
+// ReadOnly returns wether the whole form is read-only.
+func (w *InputFields) ReadOnly() bool { return w.readOnly }
+
+// SetReadOnly sets the whole form read-only (e.g. to display a document locked by another user).
+//
+// The values stay selectable/copyable but can't be modified. Inputs set read-only
+// by SetFieldReadOnly stay read-only when the form is set back to read-write.
+func (w *InputFields) SetReadOnly(b bool) {
+	w.readOnly = b
+	for _, f := range w.inputs {
+		w.applyReadOnly(f)
+	}
+}
+
+// SetFieldReadOnly sets an input read-only.
+func (w *InputFields) SetFieldReadOnly(id FieldID, b bool) {
+	if f, ok := w.inputs[id]; ok {
+		f.readOnly = b
+		w.applyReadOnly(f)
+	}
+}
+
+// GetFieldReadOnly returns wether an input is read-only (by itself or because the whole form is read-only).
+func (w *InputFields) GetFieldReadOnly(id FieldID) bool {
+	if f, ok := w.inputs[id]; ok {
+		return w.readOnly || f.readOnly
+	}
+	return false
+}
 
 // ----------------------------------------------------------------------------
 // internals
 
+func (w *InputFields) read(id FieldID) (v any, ok bool, err error) {
+	if w.inputs[id] == nil {
+		return nil, false, unknownField(id)
+	}
+	v = w.Read(id)
+	return v, v != nil, nil
+}
+
+func unknownField(id FieldID) error {
+	return fmt.Errorf("wx: unknown field %q", id)
+}
+
+func splitStrings(s string) []string {
+	if s == "" {
+		return []string{}
+	}
+	return strings.Split(s, "|")
+}
+
 func (w *InputFields) dummyId(id *FieldID) {
-	if *id == "" {
-		*id = "_noname_input_" + strconv.Itoa(len(w.order)+1)
+	if *id != "" {
+		return
+	}
+	for n := len(w.order) + 1; *id == "" || w.inputs[*id] != nil; n++ { // fields may have been removed
+		*id = "_noname_input_" + strconv.Itoa(n)
 	}
 }
 
@@ -677,7 +1206,7 @@ func (w *InputFields) addWidget(id FieldID, nullable bool, label string, wid fyn
 	f := &inputField{Widget: wid, label: label}
 
 	if dis, ok := wid.(fyne.Disableable); ok && nullable {
-		f.check = widget.NewCheck("", func(b bool) {
+		f.check = NewCheck("", func(b bool) {
 			if b {
 				dis.Enable()
 			} else {
@@ -688,27 +1217,59 @@ func (w *InputFields) addWidget(id FieldID, nullable bool, label string, wid fyn
 		f.check.Checked = true
 	}
 
-	form := w.currentForm()
+	f.labelWidget = &widget.Label{Text: label, TextStyle: fyne.TextStyle{Bold: true}}
 	if f.check == nil { // button always have check == nil
-		form.Objects = append(form.Objects,
-			&widget.Label{Text: label, TextStyle: fyne.TextStyle{Bold: true}},
-			wid,
-		)
+		f.labelCell = f.labelWidget
 	} else {
-		form.Objects = append(form.Objects,
-			container.NewBorder(nil, nil, nil, f.check, &widget.Label{Text: label, TextStyle: fyne.TextStyle{Bold: true}}),
-			wid,
-		)
+		f.labelCell = container.NewBorder(nil, nil, nil, f.check, f.labelWidget)
+	}
+
+	f.errBorder = &canvas.Rectangle{StrokeWidth: theme.InputBorderSize(), CornerRadius: theme.InputRadiusSize()}
+	f.errBorder.Hide()
+	f.errLabel = &widget.Label{Importance: widget.DangerImportance, Wrapping: fyne.TextWrapWord}
+	f.errLabel.Hide()
+	f.cell = container.NewVBox(container.NewStack(wid, f.errBorder), f.errLabel)
+
+	if v, ok := wid.(fyne.Validatable); ok {
+		v.SetOnValidationChanged(func(_ error) { w.revalidate(id) })
+	}
+
+	if w.readOnly {
+		w.applyReadOnly(f)
 	}
 
+	f.form = w.currentForm()
+	f.form.Objects = append(f.form.Objects, f.labelCell, f.cell)
+
 	w.inputs[id] = f
+	w.cells[f.cell] = f
 	w.order = append(w.order, id)
+	w.values[id] = cloneValue(w.Read(id))
+}
+
+func (w *InputFields) applyReadOnly(f *inputField) {
+	b := w.readOnly || f.readOnly
+	if ro, ok := f.Widget.(ReadOnlyable); ok && ro.ReadOnly() != b {
+		ro.SetReadOnly(b)
+	}
+	if f.check != nil {
+		f.check.SetReadOnly(b)
+	}
 }
 
 func (w *InputFields) currentVBox() *fyne.Container {
+	if w.section != nil {
+		return w.section
+	}
+	return w.topVBox()
+}
+
+// topVBox returns the content of the last tab (or the main vbox), ignoring sections.
+func (w *InputFields) topVBox() *fyne.Container {
 	if w.tabs == nil {
 		if w.vbox == nil {
 			w.vbox = container.NewVBox()
+			w.updateContent()
 		}
 		return w.vbox
 	} else {
@@ -717,21 +1278,26 @@ func (w *InputFields) currentVBox() *fyne.Container {
 }
 
 func (w *InputFields) currentForm() *fyne.Container {
-	vbox := w.currentVBox()
-	if len(vbox.Objects) <= 0 {
-		form := container.New(layout.NewFormLayout())
-		vbox.Add(form)
-		return form
-	}
-	if form, ok := vbox.Objects[len(vbox.Objects)-1].(*fyne.Container); ok {
-		return form
+	return w.lastForm(w.currentVBox())
+}
+
+func (w *InputFields) lastForm(vbox *fyne.Container) *fyne.Container {
+	if len(vbox.Objects) > 0 {
+		if form, ok := vbox.Objects[len(vbox.Objects)-1].(*fyne.Container); ok {
+			return form
+		}
 	}
-	form := container.New(layout.NewFormLayout())
+	form := container.New(w.formLayout())
 	vbox.Add(form)
 	return form
 }
 
 func (w *InputFields) onChanged(id FieldID) {
+	w.applyRules()
+	w.revalidate(id)
+	w.onChangedDirty(id)
+	w.onChangedBinding(id)
+	w.onChangedHistory(id)
 	if w.OnChanged != nil {
 		w.OnChanged(id)
 	}
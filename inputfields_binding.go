@@ -0,0 +1,105 @@
+package wx
+
+import (
+	"fmt"
+	"time"
+
+	"fyne.io/fyne/v2/data/binding"
+)
+
+// BindField connects an input to a data source.
+// Changes of the data update the input, and changes of the input are written to the data.
+//
+// Supported data types are binding.String (see ReadString/WriteString), binding.Int, binding.Float,
+// binding.Bool, binding.Item[time.Time] (see NewTimeBinding), binding.StringList
+// and binding.Untyped (see Read/Write, a nil value is a null input).
+func (w *InputFields) BindField(id FieldID, data binding.DataItem) error {
+	f := w.inputs[id]
+	if f == nil {
+		return unknownField(id)
+	}
+
+	switch data.(type) {
+	case binding.String, binding.Int, binding.Float, binding.Bool, binding.Item[time.Time], binding.StringList, binding.Untyped:
+	default:
+		return fmt.Errorf("wx: field %q: unsupported binding %T", id, data)
+	}
+
+	f.binder.bind(data, func() { w.fromData(id, data) })
+	return nil
+}
+
+// UnbindField disconnects the data source set by BindField.
+// The input keeps its current value.
+func (w *InputFields) UnbindField(id FieldID) {
+	if f := w.inputs[id]; f != nil {
+		f.binder.unbind()
+	}
+}
+
+// ----------------------------------------------------------------------------
+// internals
+
+func (w *InputFields) fromData(id FieldID, data binding.DataItem) {
+	var v any
+	var err error
+	switch data := data.(type) {
+	case binding.String:
+		v, err = data.Get()
+	case binding.Int:
+		v, err = data.Get()
+	case binding.Float:
+		v, err = data.Get()
+	case binding.Bool:
+		v, err = data.Get()
+	case binding.Item[time.Time]:
+		v, err = data.Get()
+	case binding.StringList:
+		v, err = data.Get()
+	case binding.Untyped:
+		v, err = data.Get()
+	}
+	if err != nil {
+		return
+	}
+
+	if s, ok := v.(string); ok {
+		if cur, _, _ := w.ReadString(id); cur == s {
+			return
+		}
+	} else if valuesEqual(v, w.Read(id)) {
+		return
+	}
+	w.setValue(id, v)
+}
+
+func (w *InputFields) onChangedBinding(id FieldID) {
+	f := w.inputs[id]
+	if f == nil {
+		return
+	}
+	f.binder.toData(func() {
+		switch data := f.binder.data.(type) {
+		case binding.String:
+			s, _, _ := w.ReadString(id)
+			data.Set(s)
+		case binding.Int:
+			i, _, _ := w.ReadInt(id)
+			data.Set(i)
+		case binding.Float:
+			v, _, _ := w.ReadFloat(id)
+			data.Set(v)
+		case binding.Bool:
+			b, _, _ := w.ReadBool(id)
+			data.Set(b)
+		case binding.Item[time.Time]:
+			tm, _, _ := w.ReadTime(id)
+			data.Set(tm)
+		case binding.StringList:
+			s, _, _ := w.ReadStrings(id)
+			data.Set(s)
+		case binding.Untyped:
+			data.Set(w.Read(id))
+		}
+	})
+}
@@ -0,0 +1,148 @@
+package wx
+
+import (
+	"math/big"
+	"reflect"
+	"time"
+)
+
+// MarkClean records the current values of the inputs as the reference values for dirty tracking
+// (e.g. after loading or saving a document).
+//
+// Before the first call to MarkClean, no input is considered dirty.
+func (w *InputFields) MarkClean() {
+	w.clean = w.readAll(true) // hidden inputs too, they can be shown and edited later (see ShowWhen)
+	for _, f := range w.inputs {
+		w.setDirty(f, false)
+	}
+	w.updateDirty()
+}
+
+// IsDirty returns wether at least one input differs from the values recorded by MarkClean.
+func (w *InputFields) IsDirty() bool {
+	for _, id := range w.order {
+		if w.isDirty(id) {
+			return true
+		}
+	}
+	return false
+}
+
+// DirtyFields returns the inputs that differ from the values recorded by MarkClean.
+func (w *InputFields) DirtyFields() (ids []FieldID) {
+	for _, id := range w.order {
+		if w.isDirty(id) {
+			ids = append(ids, id)
+		}
+	}
+	return
+}
+
+// Revert restores the value recorded by MarkClean for an input.
+func (w *InputFields) Revert(id FieldID) {
+	if v, ok := w.clean[id]; ok {
+		w.setValue(id, v)
+	}
+}
+
+// RevertAll restores the values recorded by MarkClean for all the inputs.
+func (w *InputFields) RevertAll() {
+	for _, id := range w.order {
+		w.Revert(id)
+	}
+}
+
+// ----------------------------------------------------------------------------
+// internals
+
+// setValue writes a value as returned by Read, handling null (nil) values.
+func (w *InputFields) setValue(id FieldID, v any) {
+	f := w.inputs[id]
+	if f == nil {
+		return
+	}
+	if v == nil && f.check != nil {
+		w.SetNull(id, true)
+		return
+	}
+	w.SetNull(id, false)
+	w.Write(id, v)
+}
+
+func (w *InputFields) isDirty(id FieldID) bool {
+	v, ok := w.clean[id]
+	if !ok {
+		return false
+	}
+	return !valuesEqual(v, w.Read(id))
+}
+
+func (w *InputFields) onChangedDirty(id FieldID) {
+	if w.clean == nil {
+		return
+	}
+	if f := w.inputs[id]; f != nil {
+		w.setDirty(f, w.isDirty(id))
+	}
+	w.updateDirty()
+}
+
+func (w *InputFields) setDirty(f *inputField, b bool) {
+	if f.dirty == b {
+		return
+	}
+	f.dirty = b
+	if w.DirtyMarker && f.labelWidget != nil {
+		if b {
+			f.labelWidget.SetText(f.label + " *")
+		} else {
+			f.labelWidget.SetText(f.label)
+		}
+	}
+}
+
+func (w *InputFields) updateDirty() {
+	if dirty := w.IsDirty(); dirty != w.dirty {
+		w.dirty = dirty
+		if w.OnDirtyChanged != nil {
+			w.OnDirtyChanged(dirty)
+		}
+	}
+}
+
+func valuesEqual(a, b any) bool {
+	if ta, ok := a.(time.Time); ok {
+		tb, ok := b.(time.Time)
+		return ok && ta.Equal(tb)
+	}
+	if ra, ok := a.([2]time.Time); ok {
+		rb, ok := b.([2]time.Time)
+		return ok && ra[0].Equal(rb[0]) && ra[1].Equal(rb[1])
+	}
+	if ra, ok := a.([]map[string]any); ok {
+		rb, ok := b.([]map[string]any)
+		if !ok || len(ra) != len(rb) {
+			return false
+		}
+		for i := range ra {
+			if len(ra[i]) != len(rb[i]) {
+				return false
+			}
+			for k, v := range ra[i] {
+				if vb, ok := rb[i][k]; !ok || !valuesEqual(v, vb) {
+					return false
+				}
+			}
+		}
+		return true
+	}
+	if ra, ok := a.(*big.Rat); ok {
+		rb, ok := b.(*big.Rat)
+		return ok && (ra == rb || ra != nil && rb != nil && ra.Cmp(rb) == 0)
+	}
+	if fa, ok := numberValue(a); ok {
+		fb, ok := numberValue(b)
+		return ok && fa == fb
+	}
+	return reflect.DeepEqual(a, b)
+}
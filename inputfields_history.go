@@ -0,0 +1,106 @@
+package wx
+
+import (
+	"math/big"
+	"time"
+)
+
+// changes of the same input closer than this are merged in one history entry (typing bursts)
+const historyCoalesceDelay = time.Second
+
+type historyEntry struct {
+	id       FieldID
+	old, new any
+}
+
+// Undo reverts the last change made to the inputs (Ctrl+Z in any input of the form).
+//
+// The history records every change of an input (see OnChanged), including programmatic ones
+// (Write...); call ClearHistory after loading a document.
+func (w *InputFields) Undo() {
+	if !w.CanUndo() {
+		return
+	}
+	w.historyPos--
+	e := w.history[w.historyPos]
+	w.applyHistory(e.id, e.old)
+}
+
+// Redo re-applies the last change reverted by Undo (Ctrl+Y in any input of the form).
+func (w *InputFields) Redo() {
+	if !w.CanRedo() {
+		return
+	}
+	e := w.history[w.historyPos]
+	w.historyPos++
+	w.applyHistory(e.id, e.new)
+}
+
+// CanUndo returns wether there is a change to undo.
+func (w *InputFields) CanUndo() bool { return w.historyPos > 0 }
+
+// CanRedo returns wether there is an undone change to redo.
+func (w *InputFields) CanRedo() bool { return w.historyPos < len(w.history) }
+
+// ClearHistory forgets all the recorded changes.
+func (w *InputFields) ClearHistory() {
+	w.history = nil
+	w.historyPos = 0
+	w.lastEdit = time.Time{}
+	for id := range w.inputs {
+		w.values[id] = cloneValue(w.Read(id))
+	}
+}
+
+// ----------------------------------------------------------------------------
+// internals
+
+func (w *InputFields) applyHistory(id FieldID, v any) {
+	w.undoing = true
+	w.setValue(id, v)
+	w.undoing = false
+	w.lastEdit = time.Time{} // next change will not be merged
+}
+
+func (w *InputFields) onChangedHistory(id FieldID) {
+	v := cloneValue(w.Read(id))
+	old, ok := w.values[id]
+	w.values[id] = v
+	if w.undoing || !ok || valuesEqual(old, v) {
+		return
+	}
+
+	now := time.Now()
+	if n := w.historyPos; n > 0 && n == len(w.history) && w.history[n-1].id == id && now.Sub(w.lastEdit) < historyCoalesceDelay {
+		w.history[n-1].new = v
+		if valuesEqual(w.history[n-1].old, v) {
+			w.history = w.history[:n-1]
+			w.historyPos--
+		}
+	} else {
+		w.history = append(w.history[:w.historyPos], historyEntry{id: id, old: old, new: v})
+		w.historyPos++
+	}
+	w.lastEdit = now
+}
+
+func cloneValue(v any) any {
+	switch v := v.(type) {
+	case []string:
+		return append([]string(nil), v...)
+	case *big.Rat:
+		if v != nil {
+			return new(big.Rat).Set(v)
+		}
+	case []map[string]any:
+		rows := make([]map[string]any, len(v))
+		for i := range v {
+			rows[i] = make(map[string]any, len(v[i]))
+			for k, x := range v[i] {
+				rows[i][k] = cloneValue(x)
+			}
+		}
+		return rows
+	}
+	return v
+}
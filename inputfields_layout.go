@@ -0,0 +1,486 @@
+package wx
+
+import (
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/layout"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+)
+
+// SetColumns sets the number of label/widget column pairs per row of the inputs added next
+// (1 by default). The following inputs start a new form.
+//
+// See also SetFieldSpan and ResponsiveWidth.
+func (w *InputFields) SetColumns(n int) {
+	if n < 1 {
+		n = 1
+	}
+	w.columns = n
+
+	vbox := w.currentVBox()
+	if len(vbox.Objects) > 0 {
+		if form, ok := vbox.Objects[len(vbox.Objects)-1].(*fyne.Container); ok && len(form.Objects) == 0 {
+			form.Layout = w.formLayout()
+			return
+		}
+	}
+	vbox.Add(container.New(w.formLayout()))
+}
+
+// SetFieldSpan sets the number of column pairs used by an input in a multi-column form (see SetColumns).
+func (w *InputFields) SetFieldSpan(id FieldID, span int) {
+	if f, ok := w.inputs[id]; ok {
+		f.span = span
+		f.form.Refresh()
+	}
+}
+
+// AddSection starts a titled section (a card, or an accordion if collapsible) in the current tab.
+// The following structure items and inputs are added to the section, until EndSection,
+// AddSection or AddTab is called.
+func (w *InputFields) AddSection(title string, collapsible bool) {
+	vbox := w.topVBox()
+	w.section = container.NewVBox()
+	if collapsible {
+		item := widget.NewAccordionItem(title, w.section)
+		item.Open = true
+		vbox.Add(widget.NewAccordion(item))
+	} else {
+		vbox.Add(widget.NewCard(title, "", w.section))
+	}
+}
+
+// EndSection ends the current section: the following items are added to the tab (see AddSection).
+func (w *InputFields) EndSection() {
+	w.section = nil
+}
+
+// RemoveField removes an input (label and widget) from the form.
+func (w *InputFields) RemoveField(id FieldID) {
+	f := w.inputs[id]
+	if f == nil {
+		return
+	}
+
+	w.detach(f)
+	w.forget(id)
+	w.updateValidity()
+	w.updateDirty()
+}
+
+// InsertFieldAfter calls add, that must add inputs (AddText, AddNumber...), and moves the added inputs
+// right after the input afterID (in the same tab). If afterID doesn't exist, the inputs are left at the end.
+//
+//	w.InsertFieldAfter("name", func() { w.AddText("nick", true, "Surnom", "", 1) })
+func (w *InputFields) InsertFieldAfter(afterID FieldID, add func()) {
+	n := len(w.order)
+	add()
+
+	prev := w.inputs[afterID]
+	if prev == nil {
+		return
+	}
+	for _, id := range append([]FieldID(nil), w.order[n:]...) {
+		f := w.inputs[id]
+		w.detach(f)
+		w.attach(f, prev.form, indexObject(prev.form.Objects, prev.cell)/2+1)
+		prev = f
+	}
+	w.reorder()
+}
+
+// MoveField moves an input to the position index (among the inputs) of the tab number tab
+// (0 if the form has no tabs). An index out of range moves the input at the end of the tab.
+func (w *InputFields) MoveField(id FieldID, tab int, index int) {
+	f := w.inputs[id]
+	vbox := w.tabVBox(tab)
+	if f == nil || vbox == nil {
+		return
+	}
+
+	w.detach(f)
+	if ids := w.fieldsOf(vbox); index >= 0 && index < len(ids) {
+		next := w.inputs[ids[index]]
+		w.attach(f, next.form, indexObject(next.form.Objects, next.cell)/2)
+	} else {
+		form := w.lastForm(vbox)
+		w.attach(f, form, len(form.Objects)/2)
+	}
+	w.reorder()
+}
+
+// RemoveTab removes a tab (by index) and all its inputs.
+func (w *InputFields) RemoveTab(tab int) {
+	vbox := w.tabVBox(tab)
+	if w.tabs == nil || vbox == nil {
+		return
+	}
+
+	for _, id := range w.fieldsOf(vbox) {
+		w.forget(id)
+	}
+	walkVBox(vbox, func(o fyne.CanvasObject, _ *fyne.Container) {
+		if rt, ok := o.(*widget.RichText); ok {
+			delete(w.markdowns, rt)
+		}
+		if o == w.section {
+			w.section = nil
+		}
+	})
+
+	w.tabs.RemoveIndex(tab)
+	if len(w.tabs.Items) == 0 {
+		w.tabs = nil
+		w.updateContent()
+	}
+	w.updateValidity()
+	w.updateDirty()
+}
+
+// Clear removes all the tabs, structure items and inputs.
+func (w *InputFields) Clear() {
+	for _, f := range w.inputs {
+		f.binder.unbind()
+	}
+
+	w.inputs = make(map[FieldID]*inputField)
+	w.order = nil
+	w.values = make(map[FieldID]any)
+	w.clean = nil
+	w.markdowns = nil
+	w.cells = make(map[fyne.CanvasObject]*inputField)
+	w.vbox, w.tabs, w.section = nil, nil, nil
+	w.ClearHistory()
+	w.updateContent()
+
+	w.updateValidity()
+	w.updateDirty()
+}
+
+// ----------------------------------------------------------------------------
+// internals
+
+func (w *InputFields) updateContent() {
+	if w.content == nil {
+		return // not rendered yet
+	}
+
+	var o fyne.CanvasObject
+	switch {
+	case w.vbox != nil:
+		o = w.vbox
+	case w.tabs != nil:
+		o = w.tabs
+	default:
+		if len(w.content.Objects) == 1 {
+			if _, ok := w.content.Objects[0].(*widget.Label); ok {
+				return
+			}
+		}
+		o = widget.NewLabel("(!) ERREUR: Aucun champs d'entrée défini.")
+	}
+
+	if len(w.content.Objects) != 1 || w.content.Objects[0] != o {
+		w.content.Objects = []fyne.CanvasObject{o}
+		w.content.Refresh()
+	}
+}
+
+// tabVBox returns the content of a tab, or the main vbox if there is no tabs.
+func (w *InputFields) tabVBox(tab int) *fyne.Container {
+	if w.tabs == nil {
+		if tab == 0 {
+			return w.topVBox()
+		}
+		return nil
+	}
+	if tab < 0 || tab >= len(w.tabs.Items) {
+		return nil
+	}
+	return w.tabs.Items[tab].Content.(*fyne.Container)
+}
+
+func (w *InputFields) vboxes() []*fyne.Container {
+	if w.tabs == nil {
+		if w.vbox == nil {
+			return nil
+		}
+		return []*fyne.Container{w.vbox}
+	}
+	ret := make([]*fyne.Container, len(w.tabs.Items))
+	for i, tab := range w.tabs.Items {
+		ret[i] = tab.Content.(*fyne.Container)
+	}
+	return ret
+}
+
+// fieldsOf returns the inputs of a vbox (and its sections), in display order.
+func (w *InputFields) fieldsOf(vbox *fyne.Container) (ids []FieldID) {
+	byCell := make(map[fyne.CanvasObject]FieldID, len(w.inputs))
+	for id, f := range w.inputs {
+		byCell[f.cell] = id
+	}
+
+	walkVBox(vbox, func(o fyne.CanvasObject, _ *fyne.Container) {
+		if form, ok := o.(*fyne.Container); ok && sectionVBox(o) == nil {
+			for i := 1; i < len(form.Objects); i += 2 {
+				if id, ok := byCell[form.Objects[i]]; ok {
+					ids = append(ids, id)
+				}
+			}
+		}
+	})
+	return
+}
+
+// sectionVBox returns the content of a section (see AddSection), or nil if o is not a section.
+func sectionVBox(o fyne.CanvasObject) *fyne.Container {
+	switch o := o.(type) {
+	case *widget.Card:
+		return o.Content.(*fyne.Container)
+	case *widget.Accordion:
+		return o.Items[0].Detail.(*fyne.Container)
+	}
+	return nil
+}
+
+// walkVBox calls fn for each object of vbox and of its sections, in display order.
+func walkVBox(vbox *fyne.Container, fn func(o fyne.CanvasObject, parent *fyne.Container)) {
+	for _, o := range vbox.Objects {
+		fn(o, vbox)
+		if sub := sectionVBox(o); sub != nil {
+			walkVBox(sub, fn)
+		}
+	}
+}
+
+// reorder rebuilds w.order from the display order.
+func (w *InputFields) reorder() {
+	w.order = nil
+	for _, vbox := range w.vboxes() {
+		w.order = append(w.order, w.fieldsOf(vbox)...)
+	}
+}
+
+// detach removes an input from its form, and the form from its vbox if it becomes empty.
+func (w *InputFields) detach(f *inputField) {
+	form := f.form
+	if i := indexObject(form.Objects, f.cell); i > 0 {
+		form.Objects = append(form.Objects[:i-1], form.Objects[i+1:]...)
+		form.Refresh()
+	}
+	f.form = nil
+
+	if len(form.Objects) > 0 {
+		return
+	}
+	for _, vbox := range w.vboxes() {
+		walkVBox(vbox, func(o fyne.CanvasObject, parent *fyne.Container) {
+			if o == form {
+				parent.Remove(form)
+			}
+		})
+	}
+}
+
+// attach inserts an input in a form, at row.
+func (w *InputFields) attach(f *inputField, form *fyne.Container, row int) {
+	objects := make([]fyne.CanvasObject, 0, len(form.Objects)+2)
+	objects = append(objects, form.Objects[:2*row]...)
+	objects = append(objects, f.labelCell, f.cell)
+	form.Objects = append(objects, form.Objects[2*row:]...)
+	f.form = form
+	form.Refresh()
+}
+
+// forget removes all references to an input (but not its widgets from the form).
+func (w *InputFields) forget(id FieldID) {
+	f := w.inputs[id]
+	f.binder.unbind()
+
+	delete(w.inputs, id)
+	delete(w.cells, f.cell)
+	delete(w.values, id)
+	delete(w.clean, id)
+	if i := indexField(w.order, id); i >= 0 {
+		w.order = append(w.order[:i:i], w.order[i+1:]...)
+	}
+
+	// drop the history entries of the input
+	history, pos := w.history[:0], w.historyPos
+	for i, e := range w.history {
+		if e.id != id {
+			history = append(history, e)
+		} else if i < w.historyPos {
+			pos--
+		}
+	}
+	w.history, w.historyPos = history, pos
+}
+
+func indexObject(objects []fyne.CanvasObject, o fyne.CanvasObject) int {
+	for i := range objects {
+		if objects[i] == o {
+			return i
+		}
+	}
+	return -1
+}
+
+func indexField(ids []FieldID, id FieldID) int {
+	for i := range ids {
+		if ids[i] == id {
+			return i
+		}
+	}
+	return -1
+}
+
+// ----------------------------------------------------------------------------
+// multi-column form layout
+
+func (w *InputFields) formLayout() fyne.Layout {
+	if w.columns > 1 {
+		return &formColumnsLayout{w: w, cols: w.columns}
+	}
+	return layout.NewFormLayout()
+}
+
+// formColumnsLayout is a form layout with cols label/widget column pairs per row.
+//
+// Like layout.NewFormLayout, objects are label/widget pairs, and a pair is skipped if both are hidden.
+type formColumnsLayout struct {
+	w        *InputFields
+	cols     int
+	lastCols int
+}
+
+type formColumnsCell struct {
+	label, content fyne.CanvasObject
+	col, span      int
+}
+
+func (l *formColumnsLayout) Layout(objects []fyne.CanvasObject, size fyne.Size) {
+	cols := l.columns(objects, size.Width)
+	if last := l.lastCols; last != cols {
+		l.lastCols = cols
+		if last != 0 {
+			l.w.columnsChanged = true // the form height changed, refreshed by InputFields.Resize
+		}
+	}
+
+	rows := l.rows(objects, cols)
+	labels, _ := l.sizes(rows, cols)
+	pad, colPad := theme.Padding(), theme.InnerPadding()
+	slot := (size.Width - float32(cols-1)*colPad) / float32(cols)
+
+	y := float32(0)
+	for _, row := range rows {
+		height := l.rowHeight(row)
+		for _, c := range row {
+			x := float32(c.col) * (slot + colPad)
+			width := float32(c.span)*slot + float32(c.span-1)*colPad
+			lw := labels[c.col]
+
+			c.label.Move(fyne.NewPos(x, y))
+			c.label.Resize(fyne.NewSize(lw, height))
+			c.content.Move(fyne.NewPos(x+lw+pad, y))
+			c.content.Resize(fyne.NewSize(width-lw-pad, height))
+		}
+		y += height + pad
+	}
+}
+
+func (l *formColumnsLayout) MinSize(objects []fyne.CanvasObject) fyne.Size {
+	cols := l.lastCols
+	if cols == 0 {
+		cols = l.cols
+	}
+	if l.w.ResponsiveWidth > 0 {
+		cols = 1 // allow to shrink down to one column
+	}
+
+	rows := l.rows(objects, cols)
+	_, slot := l.sizes(rows, cols)
+	width := float32(cols)*slot + float32(cols-1)*theme.InnerPadding()
+
+	if l.lastCols != 0 && l.lastCols != cols {
+		rows = l.rows(objects, l.lastCols) // height of the current layout
+	}
+	height := float32(0)
+	for _, row := range rows {
+		height += l.rowHeight(row)
+	}
+	if len(rows) > 1 {
+		height += float32(len(rows)-1) * theme.Padding()
+	}
+	return fyne.NewSize(width, height)
+}
+
+// columns returns the number of column pairs to use for a width.
+func (l *formColumnsLayout) columns(objects []fyne.CanvasObject, width float32) int {
+	if l.w.ResponsiveWidth <= 0 || l.cols == 1 {
+		return l.cols
+	}
+	if width < l.w.ResponsiveWidth {
+		return 1
+	}
+	_, slot := l.sizes(l.rows(objects, l.cols), l.cols)
+	if width < float32(l.cols)*slot+float32(l.cols-1)*theme.InnerPadding() {
+		return 1 // not enough space
+	}
+	return l.cols
+}
+
+// rows dispatches the visible pairs in rows of cols column pairs.
+func (l *formColumnsLayout) rows(objects []fyne.CanvasObject, cols int) (rows [][]formColumnsCell) {
+	used := cols
+	for i := 0; i+1 < len(objects); i += 2 {
+		label, content := objects[i], objects[i+1]
+		if !label.Visible() && !content.Visible() {
+			continue
+		}
+
+		span := 1
+		if f := l.w.cells[content]; f != nil && f.span > 1 {
+			span = f.span
+			if span > cols {
+				span = cols
+			}
+		}
+		if used+span > cols {
+			rows = append(rows, nil)
+			used = 0
+		}
+		rows[len(rows)-1] = append(rows[len(rows)-1], formColumnsCell{label: label, content: content, col: used, span: span})
+		used += span
+	}
+	return
+}
+
+// sizes returns the label width of each column pair, and the minimum width of a column pair.
+func (l *formColumnsLayout) sizes(rows [][]formColumnsCell, cols int) (labels []float32, slot float32) {
+	labels = make([]float32, cols)
+	for _, row := range rows {
+		for _, c := range row {
+			labels[c.col] = fyne.Max(labels[c.col], c.label.MinSize().Width)
+		}
+	}
+
+	pad, colPad := theme.Padding(), theme.InnerPadding()
+	for _, row := range rows {
+		for _, c := range row {
+			need := labels[c.col] + pad + c.content.MinSize().Width - float32(c.span-1)*colPad
+			slot = fyne.Max(slot, need/float32(c.span))
+		}
+	}
+	return
+}
+
+func (l *formColumnsLayout) rowHeight(row []formColumnsCell) (height float32) {
+	for _, c := range row {
+		height = fyne.Max(height, fyne.Max(c.label.MinSize().Height, c.content.MinSize().Height))
+	}
+	return
+}
@@ -0,0 +1,94 @@
+package wx
+
+import (
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/driver/desktop"
+	"fyne.io/fyne/v2/widget"
+)
+
+// navigate moves the focus from the focused input to the next (or previous) one that can be focused,
+// switching tabs if needed. Enter in the last input calls OnSubmit.
+func (w *InputFields) navigate(back bool) (handled bool) {
+	id := w.focusedField()
+	if id == "" {
+		return false
+	}
+	if e, ok := w.inputs[id].Widget.(*EntryEx); ok && e.MultiLine {
+		return false // Enter inserts a new line
+	}
+
+	step := 1
+	if back {
+		step = -1
+	}
+	for i := indexField(w.order, id) + step; i >= 0 && i < len(w.order); i += step {
+		if w.canFocus(w.order[i]) {
+			w.SetFocus(w.order[i])
+			return true
+		}
+	}
+
+	if !back && w.OnSubmit != nil {
+		w.OnSubmit()
+	}
+	return true
+}
+
+func (w *InputFields) focusedField() FieldID {
+	if w.win == nil {
+		return ""
+	}
+	foc := w.win.Canvas().Focused()
+	if foc == nil {
+		return ""
+	}
+	for id, f := range w.inputs {
+		if any(f.Widget) == any(foc) {
+			return id
+		}
+	}
+	return ""
+}
+
+// canFocus returns wether an input can receive the focus with EnterNavigation:
+// focusable, visible, enabled, not null and not read-only (buttons are skipped).
+func (w *InputFields) canFocus(id FieldID) bool {
+	f := w.inputs[id]
+	if _, ok := f.Widget.(fyne.Focusable); !ok {
+		return false
+	}
+	if _, ok := f.Widget.(*widget.Button); ok {
+		return false
+	}
+	if f.hidden || w.readOnly || f.readOnly || (f.check != nil && !f.check.Checked) {
+		return false
+	}
+	if dis, ok := f.Widget.(fyne.Disableable); ok && dis.Disabled() {
+		return false
+	}
+	return true
+}
+
+// selectTabOf selects the tab containing an input.
+func (w *InputFields) selectTabOf(f *inputField) {
+	if w.tabs == nil {
+		return
+	}
+	for i, vbox := range w.vboxes() {
+		found := false
+		walkVBox(vbox, func(o fyne.CanvasObject, _ *fyne.Container) { found = found || o == f.form })
+		if found {
+			if w.tabs.SelectedIndex() != i {
+				w.tabs.SelectIndex(i)
+			}
+			return
+		}
+	}
+}
+
+func shiftPressed() bool {
+	if drv, ok := fyne.CurrentApp().Driver().(desktop.Driver); ok {
+		return drv.CurrentKeyModifiers()&fyne.KeyModifierShift != 0
+	}
+	return false
+}
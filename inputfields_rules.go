@@ -0,0 +1,71 @@
+package wx
+
+// ShowWhen shows the input (label and widget) only when pred returns true.
+//
+// Rules are evaluated now and each time an input changes. Hidden inputs are
+// excluded from ReadAll and from validation.
+func (w *InputFields) ShowWhen(id FieldID, pred func(w *InputFields) bool) {
+	if f, ok := w.inputs[id]; ok {
+		f.showWhen = pred
+		w.applyRules()
+	}
+}
+
+// EnableWhen enables the input only when pred returns true (see SetStatus).
+//
+// Rules are evaluated now and each time an input changes.
+func (w *InputFields) EnableWhen(id FieldID, pred func(w *InputFields) bool) {
+	if f, ok := w.inputs[id]; ok {
+		f.enableWhen = pred
+		w.applyRules()
+	}
+}
+
+// SetVisible shows or hides an input (label and widget).
+func (w *InputFields) SetVisible(id FieldID, b bool) {
+	f, ok := w.inputs[id]
+	if !ok || f.hidden == !b {
+		return
+	}
+
+	f.hidden = !b
+	if b {
+		f.labelCell.Show()
+		f.cell.Show()
+	} else {
+		f.labelCell.Hide()
+		f.cell.Hide()
+	}
+	f.form.Refresh()
+}
+
+// GetVisible returns wether an input is visible.
+func (w *InputFields) GetVisible(id FieldID) bool {
+	if f, ok := w.inputs[id]; ok {
+		return !f.hidden
+	}
+	return false
+}
+
+// ----------------------------------------------------------------------------
+// internals
+
+func (w *InputFields) applyRules() {
+	if w.applyingRules {
+		return
+	}
+	w.applyingRules = true
+	defer func() { w.applyingRules = false }()
+
+	for _, id := range w.order {
+		f := w.inputs[id]
+		if f.showWhen != nil {
+			w.SetVisible(id, f.showWhen(w))
+		}
+		if f.enableWhen != nil {
+			if b := f.enableWhen(w); b != w.GetStatus(id) {
+				w.SetStatus(id, b)
+			}
+		}
+	}
+}
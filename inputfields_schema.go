@@ -0,0 +1,665 @@
+package wx
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/widget"
+)
+
+// InputFieldsSchema describes the structure of an InputFields as a list of items, in display order.
+//
+// It is meant to be stored as JSON (see NewInputFieldsFromSchema and ExportSchema).
+type InputFieldsSchema struct {
+	Items []InputFieldsSchemaItem `json:"items"`
+}
+
+// InputFieldsSchemaItem describes one item of an InputFields.
+//
+// Kind is one of the structure items:
+//
+//	tab         AddTab(Label)
+//	section     AddSection(Label, Collapsible)
+//	endsection  EndSection()
+//	columns     SetColumns(Columns)
+//	title       AddTitle(Text, Bold/Italic/Monospace, Align)
+//	markdown    AddTitleMkd(Text)
+//	separator   AddSeparator()
+//
+// or one of the inputs (all use ID, Label, Nullable, Null, ReadOnly, Span and Value):
+//
+//	label       AddLabel: Text, Bold/Italic/Monospace, Align
+//	text        AddText: Lines
+//	password    AddPassword (Value is not exported)
+//	number      AddNumber: Float, Signed, Min, Max, Reject, Decimals, Step, Wrap, Spinner, Decimal, Rounding, Calculator,
+//	            NumberFormat (Value is a JSON number, or a string for Decimal)
+//	date        AddDate: DateFormat (Value is an ISO 8601 date, e.g. "2024-12-31")
+//	time        AddTime: TimeFormat (Value is e.g. "23:59:00")
+//	datetime    AddDateTime: Location, DateFormat, TimeFormat (Value is e.g. "2024-12-31T23:59:00", in Location)
+//	daterange   AddDateRange: DateFormat (Value is e.g. "2024-12-01/2024-12-31", either date may be empty)
+//	select      AddSelect: Options, Editable
+//	check       AddCheck: Text
+//	checkgroup  AddCheckGroup: Options, Horizontal
+//	multitext   AddMultiText: Options
+//	radiogroup  AddRadioGroup: Options, Horizontal
+//	repeater    AddRepeater: Items (the template of a row; Value is a list of {id: value} objects)
+//	button      AddActionButton: Text, Importance
+type InputFieldsSchemaItem struct {
+	Kind string `json:"kind"`
+
+	ID       FieldID `json:"id,omitempty"`
+	Label    string  `json:"label,omitempty"`
+	Nullable bool    `json:"nullable,omitempty"`
+	Null     bool    `json:"null,omitempty"`
+	ReadOnly bool    `json:"readonly,omitempty"`
+	Span     int     `json:"span,omitempty"`
+	Value    any     `json:"value,omitempty"`
+
+	Text       string   `json:"text,omitempty"`
+	Lines      int      `json:"lines,omitempty"`
+	Float      bool     `json:"float,omitempty"`
+	Signed     bool     `json:"signed,omitempty"`
+	Min        float64  `json:"min,omitempty"`
+	Max        float64  `json:"max,omitempty"`
+	Reject     bool     `json:"reject,omitempty"`
+	Decimals   int      `json:"decimals,omitempty"`
+	Decimal    bool     `json:"decimal,omitempty"`
+	Rounding   string   `json:"rounding,omitempty"` // halfup (default), halfeven, down, up
+	Calculator bool     `json:"calculator,omitempty"`
+	Step       float64  `json:"step,omitempty"`
+	Wrap       bool     `json:"wrap,omitempty"`
+	Spinner    bool     `json:"spinner,omitempty"`
+	Options    []string `json:"options,omitempty"`
+	Editable   bool     `json:"editable,omitempty"`
+	Horizontal bool     `json:"horizontal,omitempty"`
+	Location   string   `json:"location,omitempty"`   // IANA time zone name (e.g. "Europe/Paris"), local time if empty
+	DateFormat string   `json:"dateformat,omitempty"` // layout of a DateFormat (e.g. "02/01/2006"), DefaultDateFormat if empty
+	TimeFormat string   `json:"timeformat,omitempty"` // layout of a TimeFormat (e.g. "03:04:05 PM"), DefaultTimeFormat if empty
+
+	NumberFormat *InputFieldsSchemaNumberFormat `json:"numberformat,omitempty"` // DefaultNumberFormat if nil
+
+	Items []InputFieldsSchemaItem `json:"items,omitempty"`
+
+	Collapsible bool `json:"collapsible,omitempty"`
+	Columns     int  `json:"columns,omitempty"`
+
+	Bold       bool   `json:"bold,omitempty"`
+	Italic     bool   `json:"italic,omitempty"`
+	Monospace  bool   `json:"monospace,omitempty"`
+	Align      string `json:"align,omitempty"`      // leading (default), center, trailing
+	Importance string `json:"importance,omitempty"` // medium (default), low, high, danger, warning, success
+}
+
+// InputFieldsSchemaNumberFormat is the NumberFormat of a number item, with the separators as strings.
+type InputFieldsSchemaNumberFormat struct {
+	Decimal  string `json:"decimal,omitempty"`
+	Grouping string `json:"grouping,omitempty"`
+	Prefix   string `json:"prefix,omitempty"`
+	Suffix   string `json:"suffix,omitempty"`
+	Percent  bool   `json:"percent,omitempty"`
+}
+
+// NewInputFieldsFromSchema creates an InputFields from a JSON InputFieldsSchema (see AddSchema).
+func NewInputFieldsFromSchema(win fyne.Window, schema []byte) (w *InputFields, err error) {
+	w = NewInputFields(win)
+	if err = w.AddSchema(schema); err != nil {
+		return nil, err
+	}
+	w.ClearHistory()
+	return
+}
+
+// AddSchema adds the items of a JSON InputFieldsSchema to the InputFields.
+func (w *InputFields) AddSchema(schema []byte) error {
+	var s InputFieldsSchema
+	if err := json.Unmarshal(schema, &s); err != nil {
+		return fmt.Errorf("wx: invalid schema: %w", err)
+	}
+
+	for i, item := range s.Items {
+		if err := w.addSchemaItem(item); err != nil {
+			return fmt.Errorf("wx: schema item %d: %w", i, err)
+		}
+	}
+	return nil
+}
+
+// Schema returns the structure of the InputFields, with the current values of the inputs.
+func (w *InputFields) Schema() (s InputFieldsSchema) {
+	cols := 1
+	if w.tabs != nil {
+		for _, tab := range w.tabs.Items {
+			s.Items = append(s.Items, InputFieldsSchemaItem{Kind: "tab", Label: tab.Text})
+			s.Items = w.schemaItems(s.Items, tab.Content.(*fyne.Container), &cols)
+		}
+	} else if w.vbox != nil {
+		s.Items = w.schemaItems(s.Items, w.vbox, &cols)
+	}
+	return
+}
+
+// ExportSchema returns the JSON InputFieldsSchema of the InputFields (see Schema).
+func (w *InputFields) ExportSchema() ([]byte, error) {
+	return json.MarshalIndent(w.Schema(), "", "\t")
+}
+
+// ----------------------------------------------------------------------------
+// internals
+
+func (w *InputFields) addSchemaItem(item InputFieldsSchemaItem) error {
+	style := fyne.TextStyle{Bold: item.Bold, Italic: item.Italic, Monospace: item.Monospace}
+
+	switch item.Kind {
+	case "tab":
+		w.AddTab(item.Label, nil)
+		return nil
+	case "title":
+		w.AddTitle(item.Text, style, schemaAlign(item.Align))
+		return nil
+	case "markdown":
+		w.AddTitleMkd(item.Text)
+		return nil
+	case "separator":
+		w.AddSeparator()
+		return nil
+	case "section":
+		w.AddSection(item.Label, item.Collapsible)
+		return nil
+	case "endsection":
+		w.EndSection()
+		return nil
+	case "columns":
+		w.SetColumns(item.Columns)
+		return nil
+	}
+
+	if item.ID != "" && w.inputs[item.ID] != nil {
+		return fmt.Errorf("duplicate id %q", item.ID)
+	}
+	n := len(w.order)
+
+	value := schemaString(item.Value)
+	switch item.Kind {
+	case "label":
+		w.AddLabel(item.ID, item.Label, item.Text, style, schemaAlign(item.Align))
+	case "text":
+		w.AddText(item.ID, item.Nullable, item.Label, value, item.Lines)
+	case "password":
+		w.AddPassword(item.ID, item.Nullable, item.Label, value)
+	case "number":
+		w.AddNumber(item.ID, item.Nullable, item.Label, "", item.Float, item.Signed, NumberOptions{
+			Min: item.Min, Max: item.Max, Reject: item.Reject, Decimals: item.Decimals,
+			Step: item.Step, Wrap: item.Wrap, Spinner: item.Spinner,
+			Decimal: item.Decimal, Rounding: schemaRoundings[item.Rounding], Calculator: item.Calculator,
+		})
+		if f := item.NumberFormat; f != nil {
+			w.inputs[w.order[n]].Widget.(*NumEntry).SetFormat(&NumberFormat{
+				Decimal: schemaRune(f.Decimal), Grouping: schemaRune(f.Grouping), Prefix: f.Prefix, Suffix: f.Suffix, Percent: f.Percent,
+			})
+		}
+		// values are set as numbers, the text depends on the number format
+		switch v := item.Value.(type) {
+		case float64:
+			w.Write(w.order[n], v)
+		case string: // exact decimal (see schemaField), or text in the number format
+			if r, ok := new(big.Rat).SetString(v); ok {
+				w.Write(w.order[n], r)
+			} else {
+				w.inputs[w.order[n]].Widget.(*NumEntry).SetText(v)
+			}
+		}
+	case "date":
+		f, err := schemaDateFormat(item.DateFormat)
+		if err != nil {
+			return err
+		}
+		w.AddDate(item.ID, item.Nullable, item.Label, "")
+		wid := w.inputs[w.order[n]].Widget.(*DateEntry)
+		wid.SetFormat(f) // before the value, which may be a text in this format
+		if tm, ok := parseSchemaTime(schemaDateLayout, value, time.Local); ok {
+			w.Write(w.order[n], tm)
+		} else if value != "" {
+			wid.SetText(value)
+		}
+	case "time":
+		f, err := schemaTimeFormat(item.TimeFormat)
+		if err != nil {
+			return err
+		}
+		w.AddTime(item.ID, item.Nullable, item.Label, "")
+		wid := w.inputs[w.order[n]].Widget.(*TimeEntry)
+		wid.SetFormat(f)
+		if tm, ok := parseSchemaTime(schemaTimeLayout, value, time.UTC); ok {
+			w.Write(w.order[n], tm)
+		} else if value != "" {
+			wid.SetText(value)
+		}
+	case "datetime":
+		df, err := schemaDateFormat(item.DateFormat)
+		if err != nil {
+			return err
+		}
+		tf, err := schemaTimeFormat(item.TimeFormat)
+		if err != nil {
+			return err
+		}
+		var loc *time.Location
+		if item.Location != "" {
+			if loc, err = time.LoadLocation(item.Location); err != nil {
+				return err
+			}
+		}
+		w.AddDateTime(item.ID, item.Nullable, item.Label, "", loc)
+		wid := w.inputs[w.order[n]].Widget.(*DateTimeEntry)
+		wid.SetDateFormat(df)
+		wid.SetTimeFormat(tf)
+		if tm, ok := parseSchemaTime(schemaDateTimeLayout, value, loc); ok {
+			w.Write(w.order[n], tm)
+		} else if value != "" {
+			wid.SetText(value)
+		}
+	case "daterange":
+		f, err := schemaDateFormat(item.DateFormat)
+		if err != nil {
+			return err
+		}
+		w.AddDateRange(item.ID, item.Nullable, item.Label, "", "")
+		wid := w.inputs[w.order[n]].Widget.(*DateRangeEntry)
+		wid.SetFormat(f)
+		from, to, _ := strings.Cut(value, "/")
+		tmFrom, isoFrom := parseSchemaTime(schemaDateLayout, from, time.Local)
+		tmTo, isoTo := parseSchemaTime(schemaDateLayout, to, time.Local)
+		if (isoFrom || from == "") && (isoTo || to == "") && value != "" {
+			w.Write(w.order[n], [2]time.Time{tmFrom, tmTo})
+		} else if value != "" {
+			wid.SetText(value) // "from - to"
+		}
+	case "select":
+		w.AddSelect(item.ID, item.Nullable, item.Label, item.Options, value, item.Editable)
+	case "check":
+		b, _ := item.Value.(bool)
+		w.AddCheck(item.ID, item.Nullable, item.Label, item.Text, b)
+	case "checkgroup":
+		w.AddCheckGroup(item.ID, item.Nullable, item.Label, item.Options, schemaStrings(item.Value), item.Horizontal)
+	case "multitext":
+		w.AddMultiText(item.ID, item.Nullable, item.Label, schemaStrings(item.Value), item.Options)
+	case "radiogroup":
+		w.AddRadioGroup(item.ID, item.Nullable, item.Label, item.Options, value, item.Horizontal)
+	case "repeater":
+		for i, sub := range item.Items {
+			if err := NewInputFields(nil).addSchemaItem(sub); err != nil {
+				return fmt.Errorf("repeater item %d: %w", i, err)
+			}
+		}
+		w.AddRepeater(item.ID, item.Nullable, item.Label, func(sub *InputFields) {
+			for _, it := range item.Items {
+				sub.addSchemaItem(it)
+			}
+		})
+		if item.Value != nil {
+			w.Write(w.order[n], item.Value)
+		}
+	case "button":
+		w.AddActionButton(item.ID, item.Label, item.Text, schemaImportance(item.Importance))
+	default:
+		return fmt.Errorf("unknown kind %q", item.Kind)
+	}
+
+	id := w.order[n] // item.ID may be empty
+	if item.Null {
+		w.SetNull(id, true)
+	}
+	if item.ReadOnly {
+		w.SetFieldReadOnly(id, true)
+	}
+	if item.Span > 1 {
+		w.SetFieldSpan(id, item.Span)
+	}
+	return nil
+}
+
+func (w *InputFields) schemaItems(items []InputFieldsSchemaItem, vbox *fyne.Container, cols *int) []InputFieldsSchemaItem {
+	cells := make(map[fyne.CanvasObject]FieldID, len(w.inputs))
+	for id, f := range w.inputs {
+		cells[f.cell] = id
+	}
+
+	for _, o := range vbox.Objects {
+		switch o := o.(type) {
+		case *widget.Separator:
+			items = append(items, InputFieldsSchemaItem{Kind: "separator"})
+		case *widget.Label:
+			items = append(items, InputFieldsSchemaItem{Kind: "title", Text: o.Text,
+				Bold: o.TextStyle.Bold, Italic: o.TextStyle.Italic, Monospace: o.TextStyle.Monospace,
+				Align: schemaAlignName(o.Alignment),
+			})
+		case *widget.RichText:
+			items = append(items, InputFieldsSchemaItem{Kind: "markdown", Text: w.markdowns[o]})
+		case *widget.Card:
+			items = append(items, InputFieldsSchemaItem{Kind: "section", Label: o.Title})
+			items = w.schemaItems(items, sectionVBox(o), cols)
+			items = append(items, InputFieldsSchemaItem{Kind: "endsection"})
+		case *widget.Accordion:
+			items = append(items, InputFieldsSchemaItem{Kind: "section", Label: o.Items[0].Title, Collapsible: true})
+			items = w.schemaItems(items, sectionVBox(o), cols)
+			items = append(items, InputFieldsSchemaItem{Kind: "endsection"})
+		case *fyne.Container: // form
+			n := 1
+			if l, ok := o.Layout.(*formColumnsLayout); ok {
+				n = l.cols
+			}
+			if n != *cols {
+				items = append(items, InputFieldsSchemaItem{Kind: "columns", Columns: n})
+				*cols = n
+			}
+			for i := 1; i < len(o.Objects); i += 2 {
+				if id, ok := cells[o.Objects[i]]; ok {
+					items = append(items, w.schemaField(id))
+				}
+			}
+		}
+	}
+	return items
+}
+
+func (w *InputFields) schemaField(id FieldID) (item InputFieldsSchemaItem) {
+	f := w.inputs[id]
+	item = InputFieldsSchemaItem{
+		ID:       id,
+		Label:    f.label,
+		Nullable: f.check != nil,
+		Null:     f.check != nil && !f.check.Checked,
+		ReadOnly: f.readOnly,
+		Span:     f.span,
+		Value:    w.Read(id),
+	}
+
+	switch wid := f.Widget.(type) {
+	case *widget.Label:
+		item.Kind = "label"
+		item.Text = wid.Text
+		item.Bold, item.Italic, item.Monospace = wid.TextStyle.Bold, wid.TextStyle.Italic, wid.TextStyle.Monospace
+		item.Align = schemaAlignName(wid.Alignment)
+		item.Value = nil
+	case *EntryEx:
+		if wid.Password {
+			item.Kind = "password"
+			item.Value = nil // never stored
+		} else {
+			item.Kind = "text"
+			item.Lines = wid.minRows
+		}
+	case *NumEntry:
+		item.Kind = "number"
+		item.Float = wid.Float
+		item.Signed = wid.Signed
+		item.Min, item.Max, item.Reject, item.Decimals = wid.Min, wid.Max, wid.Reject, wid.Decimals
+		item.Step, item.Wrap, item.Spinner = wid.Step, wid.Wrap, wid.Spinner
+		item.Calculator = wid.Calculator
+		if f := wid.Format; f != nil {
+			item.NumberFormat = &InputFieldsSchemaNumberFormat{Prefix: f.Prefix, Suffix: f.Suffix, Percent: f.Percent}
+			if f.Decimal != 0 {
+				item.NumberFormat.Decimal = string(f.Decimal)
+			}
+			if f.Grouping != 0 {
+				item.NumberFormat.Grouping = string(f.Grouping)
+			}
+		}
+		if item.Decimal = wid.Decimal; item.Decimal {
+			item.Rounding = schemaRoundingName(wid.Rounding)
+			if r, ok := item.Value.(*big.Rat); ok {
+				item.Value = ratString(r) // exact value, as a string (big.Rat marshals as a fraction)
+			}
+		}
+	case *DateEntry:
+		item.Kind = "date"
+		item.DateFormat = schemaDateFormatName(wid.Format)
+		if !item.Null {
+			item.Value = schemaTime(wid.GetTime(), schemaDateLayout)
+		}
+	case *TimeEntry:
+		item.Kind = "time"
+		item.TimeFormat = schemaTimeFormatName(wid.Format)
+		if !item.Null {
+			item.Value = schemaTime(wid.GetTime(), schemaTimeLayout)
+		}
+	case *DateTimeEntry:
+		item.Kind = "datetime"
+		if wid.Location != nil && wid.Location != time.Local {
+			item.Location = wid.Location.String()
+		}
+		item.DateFormat = schemaDateFormatName(wid.Date().Format)
+		item.TimeFormat = schemaTimeFormatName(wid.Time().Format)
+		if !item.Null {
+			item.Value = schemaTime(wid.GetTime(), schemaDateTimeLayout)
+		}
+	case *DateRangeEntry:
+		item.Kind = "daterange"
+		item.DateFormat = schemaDateFormatName(wid.From().Format)
+		if !item.Null {
+			item.Value = ""
+			if from, to := wid.GetRange(); !from.IsZero() || !to.IsZero() {
+				item.Value = schemaTime(from, schemaDateLayout) + "/" + schemaTime(to, schemaDateLayout)
+			}
+		}
+	case *Select:
+		item.Kind = "select"
+		item.Options = wid.Options
+	case *SelectEntry:
+		item.Kind = "select"
+		item.Options = wid.Options()
+		item.Editable = true
+	case *Check:
+		item.Kind = "check"
+		item.Text = wid.Text
+	case *CheckGroup:
+		item.Kind = "checkgroup"
+		item.Options = wid.Options
+		item.Horizontal = wid.Horizontal
+	case *MultiEntry:
+		item.Kind = "multitext"
+		item.Options = wid.Options
+	case *RadioGroup:
+		item.Kind = "radiogroup"
+		item.Options = wid.Options
+		item.Horizontal = wid.Horizontal
+	case *Repeater:
+		item.Kind = "repeater"
+		sub := NewInputFields(nil)
+		if wid.template != nil {
+			wid.template(sub)
+		}
+		item.Items = sub.Schema().Items
+		for i := range item.Items {
+			item.Items[i].Value = nil
+		}
+		if !item.Null {
+			rows := make([]map[string]any, wid.Len())
+			for i := range rows {
+				rows[i] = schemaRow(wid.Row(i))
+			}
+			item.Value = rows
+		}
+	case *widget.Button:
+		item.Kind = "button"
+		item.Text = wid.Text
+		item.Importance = schemaImportanceName(wid.Importance)
+		item.Value = nil
+	}
+	return
+}
+
+// schemaRow returns the textual values of a repeater row (dates are not JSON friendly), without passwords.
+func schemaRow(sub *InputFields) map[string]any {
+	row := make(map[string]any)
+	for id, s := range sub.ReadAllString() {
+		if e, ok := sub.Widget(id).(*EntryEx); ok && e.Password {
+			continue
+		}
+		if sub.GetNull(id) {
+			row[id] = nil
+		} else {
+			row[id] = s
+		}
+	}
+	return row
+}
+
+func schemaString(v any) string {
+	switch v := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case []any:
+		return fmt.Sprint(schemaStrings(v))
+	}
+	return fmt.Sprint(v)
+}
+
+// schemaRune returns the first rune of s, 0 if s is empty.
+func schemaRune(s string) rune {
+	for _, r := range s {
+		return r
+	}
+	return 0
+}
+
+func schemaStrings(v any) (ret []string) {
+	switch v := v.(type) {
+	case []any:
+		for _, s := range v {
+			ret = append(ret, schemaString(s))
+		}
+	case string:
+		ret = []string{v}
+	}
+	return
+}
+
+// layouts of the schema values of dates and times, independent of the date and time formats
+const (
+	schemaDateLayout     = "2006-01-02"
+	schemaTimeLayout     = "15:04:05"
+	schemaDateTimeLayout = schemaDateLayout + "T" + schemaTimeLayout
+)
+
+// schemaTime formats tm with layout, "" for the zero time.
+func schemaTime(tm time.Time, layout string) string {
+	if tm.IsZero() {
+		return ""
+	}
+	return tm.Format(layout)
+}
+
+// parseSchemaTime parses a schema value in loc (time.Local if nil), ok is false if s is not in layout
+// (e.g. the text of a date in schemas of older versions).
+func parseSchemaTime(layout, s string, loc *time.Location) (tm time.Time, ok bool) {
+	if loc == nil {
+		loc = time.Local
+	}
+	tm, err := time.ParseInLocation(layout, strings.TrimSpace(s), loc)
+	return tm, err == nil
+}
+
+func schemaDateFormat(layout string) (*DateFormat, error) {
+	if layout == "" {
+		return nil, nil
+	}
+	if i := strings.IndexFunc(layout, func(r rune) bool { return r < '0' || r > '9' }); i > 0 {
+		sep, _ := utf8.DecodeRuneInString(layout[i:])
+		for _, order := range []DateOrder{DMY, MDY, YMD} {
+			if f := (DateFormat{Order: order, Separator: sep}); f.Layout() == layout {
+				return &f, nil
+			}
+		}
+	}
+	return nil, fmt.Errorf("invalid date format %q", layout)
+}
+
+func schemaDateFormatName(f *DateFormat) string {
+	if f == nil {
+		return ""
+	}
+	return f.Layout()
+}
+
+func schemaTimeFormat(layout string) (*TimeFormat, error) {
+	if layout == "" {
+		return nil, nil
+	}
+	for _, f := range []TimeFormat{{}, {Seconds: true}, {Hour12: true}, {Seconds: true, Hour12: true}} {
+		if f.Layout() == layout {
+			return &f, nil
+		}
+	}
+	return nil, fmt.Errorf("invalid time format %q", layout)
+}
+
+func schemaTimeFormatName(f *TimeFormat) string {
+	if f == nil {
+		return ""
+	}
+	return f.Layout()
+}
+
+var schemaAligns = map[string]fyne.TextAlign{
+	"leading":  fyne.TextAlignLeading,
+	"center":   fyne.TextAlignCenter,
+	"trailing": fyne.TextAlignTrailing,
+}
+
+var schemaRoundings = map[string]RoundingMode{
+	"halfup":   RoundHalfUp,
+	"halfeven": RoundHalfEven,
+	"down":     RoundDown,
+	"up":       RoundUp,
+}
+
+func schemaRoundingName(m RoundingMode) string {
+	for k, v := range schemaRoundings {
+		if v == m && m != RoundHalfUp {
+			return k
+		}
+	}
+	return ""
+}
+
+func schemaAlign(s string) fyne.TextAlign { return schemaAligns[s] }
+
+func schemaAlignName(a fyne.TextAlign) string {
+	for k, v := range schemaAligns {
+		if v == a && a != fyne.TextAlignLeading {
+			return k
+		}
+	}
+	return ""
+}
+
+var schemaImportances = map[string]widget.Importance{
+	"medium":  widget.MediumImportance,
+	"low":     widget.LowImportance,
+	"high":    widget.HighImportance,
+	"danger":  widget.DangerImportance,
+	"warning": widget.WarningImportance,
+	"success": widget.SuccessImportance,
+}
+
+func schemaImportance(s string) widget.Importance { return schemaImportances[s] }
+
+func schemaImportanceName(i widget.Importance) string {
+	for k, v := range schemaImportances {
+		if v == i && i != widget.MediumImportance {
+			return k
+		}
+	}
+	return ""
+}
@@ -0,0 +1,461 @@
+package wx
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"fyne.io/fyne/v2"
+)
+
+// Struct tags
+//
+// Only the struct fields having a `wx` tag are used. The tag is a comma separated list,
+// the first element is the FieldID (field name if empty, "-" to ignore the field),
+// followed by options:
+//
+//	label=Text     input label (field name if empty)
+//	nullable       adds the null check (implicit for pointer fields)
+//	lines=N        multiline text entry
+//	float          float number (implicit for float32/float64 fields)
+//	signed         signed number
+//	min=N, max=N   number bounds (see NumberOptions)
+//	reject         out of range numbers are rejected instead of being clamped
+//	decimals=N     fixed number of fraction digits
+//	decimal        exact decimal number (implicit for big.Rat fields)
+//	step=N         number step
+//	wrap           stepping past max goes to min, and conversely
+//	spinner        shows up/down arrows in the number input
+//	calculator     arithmetic expressions are accepted in the number input
+//	options=a|b|c  select options (string fields) or check group options ([]string fields, a list of texts is used without options)
+//	editable       editable select, or list of texts with auto-completion ([]string fields)
+//	radio          radio group instead of a select
+//	horizontal     horizontal check/radio group
+//	password       password entry
+//	time           time of day input instead of a date (time.Time fields)
+//	datetime       date and time input instead of a date, in local time (time.Time fields)
+//	text=Text      check box text (bool fields)
+//	tab=Title      starts a new tab with this title
+//	readonly       read-only input
+//
+// Supported field types are string, bool, int/uint/float kinds, big.Rat, time.Time, [2]time.Time (period), []string,
+// and pointers to these (a nil pointer is a null value).
+
+type structTag struct {
+	id, label  string
+	nullable   bool
+	lines      int
+	float      bool
+	signed     bool
+	number     NumberOptions
+	options    []string
+	editable   bool
+	radio      bool
+	horizontal bool
+	password   bool
+	time       bool
+	datetime   bool
+	text       string
+	tab        string
+	readonly   bool
+}
+
+var (
+	timeType  = reflect.TypeOf(time.Time{})
+	ratType   = reflect.TypeOf(big.Rat{})
+	rangeType = reflect.TypeOf([2]time.Time{})
+)
+
+// NewInputFieldsFromStruct creates an InputFields from the `wx` tags of the struct pointed to by v
+// (see AddStruct).
+func NewInputFieldsFromStruct(win fyne.Window, v any) (w *InputFields, err error) {
+	w = NewInputFields(win)
+	if err = w.AddStruct(v); err != nil {
+		return nil, err
+	}
+	w.ClearHistory()
+	return
+}
+
+// AddStruct adds one input for each tagged field of the struct pointed to by v,
+// then fills the inputs with the struct values (see Bind).
+func (w *InputFields) AddStruct(v any) error {
+	rv, err := structValue(v)
+	if err != nil {
+		return err
+	}
+
+	curTab := ""
+	for _, sf := range reflect.VisibleFields(rv.Type()) {
+		tag, ok := parseStructTag(sf)
+		if !ok {
+			continue
+		}
+
+		if tag.tab != "" && tag.tab != curTab {
+			w.AddTab(tag.tab, nil)
+			curTab = tag.tab
+		}
+
+		if err := w.addStructField(sf, tag); err != nil {
+			return err
+		}
+	}
+
+	return w.Bind(v)
+}
+
+// Bind fills the inputs with the values of the tagged fields of the struct (or pointer to struct) v.
+//
+// Struct fields without a matching input are ignored.
+func (w *InputFields) Bind(v any) error {
+	rv, err := structValue(v)
+	if err != nil {
+		return err
+	}
+
+	for _, sf := range reflect.VisibleFields(rv.Type()) {
+		tag, ok := parseStructTag(sf)
+		if !ok || w.inputs[tag.id] == nil {
+			continue
+		}
+
+		fv, err := rv.FieldByIndexErr(sf.Index)
+		if err != nil {
+			continue // nil embedded pointer
+		}
+
+		if fv.Kind() == reflect.Pointer {
+			if fv.IsNil() {
+				w.SetNull(tag.id, true)
+				continue
+			}
+			fv = fv.Elem()
+		}
+		w.SetNull(tag.id, false)
+
+		switch {
+		case fv.Type() == timeType:
+			w.Write(tag.id, fv.Interface())
+		case fv.Type() == rangeType:
+			w.Write(tag.id, fv.Interface())
+		case fv.Type() == ratType:
+			r := fv.Interface().(big.Rat)
+			w.Write(tag.id, new(big.Rat).Set(&r))
+		case fv.Kind() == reflect.String:
+			w.Write(tag.id, fv.String())
+		case fv.Kind() == reflect.Bool:
+			w.Write(tag.id, fv.Bool())
+		case fv.CanInt():
+			w.Write(tag.id, fv.Int())
+		case fv.CanUint():
+			w.Write(tag.id, fv.Uint())
+		case fv.CanFloat():
+			w.Write(tag.id, fv.Float())
+		case fv.Kind() == reflect.Slice && fv.Type().Elem().Kind() == reflect.String:
+			s := make([]string, fv.Len())
+			for i := range s {
+				s[i] = fv.Index(i).String()
+			}
+			w.Write(tag.id, s)
+		default:
+			return fmt.Errorf("wx: unsupported type %s for field %s", sf.Type, sf.Name)
+		}
+	}
+	return nil
+}
+
+// Unmarshal writes the inputs values back into the tagged fields of the struct pointed to by v.
+//
+// A null input sets the field to its zero value (nil for pointers).
+// Struct fields without a matching input are left untouched.
+func (w *InputFields) Unmarshal(v any) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() {
+		return errors.New("wx: Unmarshal needs a non-nil pointer to a struct")
+	}
+	if _, err := structValue(v); err != nil {
+		return err
+	}
+	rv = rv.Elem()
+
+	for _, sf := range reflect.VisibleFields(rv.Type()) {
+		tag, ok := parseStructTag(sf)
+		if !ok || w.inputs[tag.id] == nil {
+			continue
+		}
+
+		fv, err := rv.FieldByIndexErr(sf.Index)
+		if err != nil {
+			continue // nil embedded pointer
+		}
+
+		if err := assignValue(fv, w.Read(tag.id)); err != nil {
+			return fmt.Errorf("wx: field %s: %w", sf.Name, err)
+		}
+	}
+	return nil
+}
+
+// ----------------------------------------------------------------------------
+// internals
+
+func (w *InputFields) addStructField(sf reflect.StructField, tag structTag) error {
+	t := sf.Type
+	if t.Kind() == reflect.Pointer {
+		tag.nullable = true
+		t = t.Elem()
+	}
+
+	switch {
+	case t == timeType && tag.time:
+		w.AddTime(tag.id, tag.nullable, tag.label, "")
+	case t == timeType && tag.datetime:
+		w.AddDateTime(tag.id, tag.nullable, tag.label, "", nil)
+	case t == timeType:
+		w.AddDate(tag.id, tag.nullable, tag.label, "")
+	case t == rangeType:
+		w.AddDateRange(tag.id, tag.nullable, tag.label, "", "")
+	case t == ratType:
+		tag.number.Decimal = true
+		w.AddNumber(tag.id, tag.nullable, tag.label, "", true, tag.signed, tag.number)
+	case t.Kind() == reflect.String:
+		switch {
+		case tag.password:
+			w.AddPassword(tag.id, tag.nullable, tag.label, "")
+		case tag.radio:
+			w.AddRadioGroup(tag.id, tag.nullable, tag.label, tag.options, "", tag.horizontal)
+		case len(tag.options) > 0 || tag.editable:
+			w.AddSelect(tag.id, tag.nullable, tag.label, tag.options, "", tag.editable)
+		default:
+			w.AddText(tag.id, tag.nullable, tag.label, "", tag.lines)
+		}
+	case t.Kind() == reflect.Bool:
+		w.AddCheck(tag.id, tag.nullable, tag.label, tag.text, false)
+	case t.Kind() >= reflect.Int && t.Kind() <= reflect.Uint64:
+		w.AddNumber(tag.id, tag.nullable, tag.label, "", tag.float, tag.signed && t.Kind() <= reflect.Int64, tag.number)
+	case t.Kind() == reflect.Float32 || t.Kind() == reflect.Float64:
+		w.AddNumber(tag.id, tag.nullable, tag.label, "", true, tag.signed, tag.number)
+	case t.Kind() == reflect.Slice && t.Elem().Kind() == reflect.String && (len(tag.options) == 0 || tag.editable):
+		w.AddMultiText(tag.id, tag.nullable, tag.label, nil, tag.options)
+	case t.Kind() == reflect.Slice && t.Elem().Kind() == reflect.String:
+		w.AddCheckGroup(tag.id, tag.nullable, tag.label, tag.options, nil, tag.horizontal)
+	default:
+		return fmt.Errorf("wx: unsupported type %s for field %s", sf.Type, sf.Name)
+	}
+
+	if tag.readonly {
+		w.SetFieldReadOnly(tag.id, true)
+	}
+	return nil
+}
+
+func structValue(v any) (rv reflect.Value, err error) {
+	rv = reflect.ValueOf(v)
+	if rv.Kind() == reflect.Pointer {
+		if rv.IsNil() {
+			return rv, errors.New("wx: nil struct pointer")
+		}
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct {
+		return rv, fmt.Errorf("wx: %T is not a struct", v)
+	}
+	return
+}
+
+func parseStructTag(sf reflect.StructField) (tag structTag, ok bool) {
+	if !sf.IsExported() || sf.Anonymous {
+		return
+	}
+
+	s, ok := sf.Tag.Lookup("wx")
+	if !ok || s == "-" {
+		return tag, false
+	}
+
+	parts := strings.Split(s, ",")
+	tag.id = strings.TrimSpace(parts[0])
+	if tag.id == "" {
+		tag.id = sf.Name
+	}
+	tag.label = sf.Name
+
+	for _, p := range parts[1:] {
+		key, value, _ := strings.Cut(strings.TrimSpace(p), "=")
+		switch key {
+		case "label":
+			tag.label = value
+		case "nullable":
+			tag.nullable = true
+		case "lines":
+			tag.lines, _ = strconv.Atoi(value)
+		case "float":
+			tag.float = true
+		case "signed":
+			tag.signed = true
+		case "min":
+			tag.number.Min, _ = strconv.ParseFloat(value, 64)
+		case "max":
+			tag.number.Max, _ = strconv.ParseFloat(value, 64)
+		case "reject":
+			tag.number.Reject = true
+		case "decimals":
+			tag.number.Decimals, _ = strconv.Atoi(value)
+		case "step":
+			tag.number.Step, _ = strconv.ParseFloat(value, 64)
+		case "wrap":
+			tag.number.Wrap = true
+		case "spinner":
+			tag.number.Spinner = true
+		case "decimal":
+			tag.number.Decimal = true
+		case "calculator":
+			tag.number.Calculator = true
+		case "options":
+			if value != "" {
+				tag.options = strings.Split(value, "|")
+			}
+		case "editable":
+			tag.editable = true
+		case "radio":
+			tag.radio = true
+		case "horizontal":
+			tag.horizontal = true
+		case "password":
+			tag.password = true
+		case "time":
+			tag.time = true
+		case "datetime":
+			tag.datetime = true
+		case "text":
+			tag.text = value
+		case "tab":
+			tag.tab = value
+		case "readonly":
+			tag.readonly = true
+		}
+	}
+	return tag, true
+}
+
+func assignValue(dst reflect.Value, value any) error {
+	if value == nil {
+		dst.Set(reflect.Zero(dst.Type()))
+		return nil
+	}
+
+	if dst.Kind() == reflect.Pointer {
+		elem := reflect.New(dst.Type().Elem())
+		if err := assignValue(elem.Elem(), value); err != nil {
+			return err
+		}
+		dst.Set(elem)
+		return nil
+	}
+
+	switch {
+	case dst.Type() == timeType:
+		if t, ok := value.(time.Time); ok {
+			dst.Set(reflect.ValueOf(t))
+			return nil
+		}
+	case dst.Type() == rangeType:
+		if r, ok := value.([2]time.Time); ok {
+			dst.Set(reflect.ValueOf(r))
+			return nil
+		}
+	case dst.Type() == ratType:
+		if r, ok := value.(*big.Rat); ok {
+			if r == nil {
+				r = new(big.Rat)
+			}
+			dst.Set(reflect.ValueOf(*new(big.Rat).Set(r)))
+			return nil
+		}
+		if f, ok := numberValue(value); ok {
+			dst.Set(reflect.ValueOf(*ratFromFloat(f)))
+			return nil
+		}
+	case dst.Kind() == reflect.String:
+		switch v := value.(type) {
+		case string:
+			dst.SetString(v)
+		case []string:
+			dst.SetString(strings.Join(v, "|"))
+		case *big.Rat:
+			if v != nil {
+				dst.SetString(ratString(v))
+			} else {
+				dst.SetString("")
+			}
+		default:
+			dst.SetString(fmt.Sprint(v))
+		}
+		return nil
+	case dst.Kind() == reflect.Bool:
+		if b, ok := value.(bool); ok {
+			dst.SetBool(b)
+			return nil
+		}
+	case dst.CanInt():
+		if rv := reflect.ValueOf(value); rv.CanInt() {
+			if dst.OverflowInt(rv.Int()) {
+				return fmt.Errorf("%v overflows %s", value, dst.Type())
+			}
+			dst.SetInt(rv.Int())
+			return nil
+		}
+		if f, ok := numberValue(value); ok {
+			if dst.OverflowInt(int64(f)) {
+				return fmt.Errorf("%v overflows %s", value, dst.Type())
+			}
+			dst.SetInt(int64(f))
+			return nil
+		}
+	case dst.CanUint():
+		if f, ok := numberValue(value); ok {
+			if f < 0 || dst.OverflowUint(uint64(f)) {
+				return fmt.Errorf("%v overflows %s", value, dst.Type())
+			}
+			dst.SetUint(uint64(f))
+			return nil
+		}
+	case dst.CanFloat():
+		if f, ok := numberValue(value); ok {
+			dst.SetFloat(f)
+			return nil
+		}
+	case dst.Kind() == reflect.Slice && dst.Type().Elem().Kind() == reflect.String:
+		if s, ok := value.([]string); ok {
+			ret := reflect.MakeSlice(dst.Type(), len(s), len(s))
+			for i := range s {
+				ret.Index(i).SetString(s[i])
+			}
+			dst.Set(ret)
+			return nil
+		}
+	}
+	return fmt.Errorf("cannot assign %T to %s", value, dst.Type())
+}
+
+func numberValue(value any) (f float64, ok bool) {
+	if r, isRat := value.(*big.Rat); isRat && r != nil {
+		f, _ = r.Float64()
+		return f, true
+	}
+	rv := reflect.ValueOf(value)
+	switch {
+	case rv.CanInt():
+		return float64(rv.Int()), true
+	case rv.CanUint():
+		return float64(rv.Uint()), true
+	case rv.CanFloat():
+		return rv.Float(), true
+	}
+	return
+}
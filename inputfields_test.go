@@ -0,0 +1,900 @@
+package wx
+
+import (
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/data/binding"
+	"fyne.io/fyne/v2/test"
+)
+
+type testPerson struct {
+	Name     string    `wx:"name,label=Nom"`
+	Age      int       `wx:"age,label=Age"`
+	Height   float64   `wx:"height,label=Taille"`
+	Birth    time.Time `wx:"birth,label=Naissance"`
+	Married  bool      `wx:"married,label=Marié"`
+	Sex      string    `wx:"sex,label=Sexe,options=M|F"`
+	Tags     []string  `wx:"tags,label=Tags,options=a|b|c"`
+	Nickname *string   `wx:"nick,label=Surnom"`
+	Ignored  string
+}
+
+func TestInputFieldsStruct(t *testing.T) {
+	test.NewTempApp(t)
+
+	nick := "Bob"
+	in := testPerson{
+		Name:     "Robert",
+		Age:      42,
+		Height:   1.85,
+		Birth:    time.Date(1982, 3, 15, 0, 0, 0, 0, time.Local),
+		Married:  true,
+		Sex:      "M",
+		Tags:     []string{"a", "c"},
+		Nickname: &nick,
+		Ignored:  "ignored",
+	}
+
+	w, err := NewInputFieldsFromStruct(test.NewWindow(nil), &in)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(w.Inputs()) != 8 {
+		t.Fatalf("len(w.Inputs()) != 8 (%d)", len(w.Inputs()))
+	}
+	if w.Label("name") != "Nom" {
+		t.Fatal("w.Label(name) != Nom")
+	}
+
+	var out testPerson
+	if err := w.Unmarshal(&out); err != nil {
+		t.Fatal(err)
+	}
+	if out.Name != in.Name || out.Age != in.Age || out.Height != in.Height || !out.Birth.Equal(in.Birth) ||
+		out.Married != in.Married || out.Sex != in.Sex || len(out.Tags) != 2 || out.Nickname == nil || *out.Nickname != nick {
+		t.Fatalf("out != in (%+v)", out)
+	}
+	if out.Ignored != "" {
+		t.Fatal("out.Ignored != \"\"")
+	}
+
+	w.SetNull("nick", true)
+	if err := w.Unmarshal(&out); err != nil {
+		t.Fatal(err)
+	}
+	if out.Nickname != nil {
+		t.Fatal("out.Nickname != nil")
+	}
+}
+
+func TestInputFieldsValidation(t *testing.T) {
+	test.NewTempApp(t)
+
+	w := NewInputFields(test.NewWindow(nil))
+	w.AddText("name", false, "Nom", "", 1)
+	w.AddText("nick", true, "Surnom", "", 1)
+	w.AddNumber("age", false, "Age", "", false, false)
+	w.AddDate("birth", false, "Naissance", "")
+
+	valid := true
+	w.OnValidationChanged = func(b bool) { valid = b }
+
+	w.AddValidator("name", ValidateRequired(), ValidateMinLength(3))
+	w.AddValidator("nick", ValidateRequired())
+	w.AddValidator("age", ValidateRange(18, 99))
+	if valid {
+		t.Fatal("form should be invalid")
+	}
+
+	errs := w.Validate()
+	if len(errs) != 2 || errs["name"] == nil || errs["nick"] == nil {
+		t.Fatalf("unexpected errors %v", errs)
+	}
+	if w.ValidationError("name") == nil {
+		t.Fatal("name error should be displayed")
+	}
+
+	w.Write("name", "Bo")
+	if w.ValidationError("name") == nil {
+		t.Fatal("name should be too short")
+	}
+	w.Write("name", "Bob")
+	if w.ValidationError("name") != nil {
+		t.Fatal("name should be valid")
+	}
+
+	w.SetNull("nick", true)
+	if !valid {
+		t.Fatal("form should be valid")
+	}
+
+	w.Write("age", 12)
+	if valid {
+		t.Fatal("age should be out of range")
+	}
+	w.Write("age", 20)
+
+	w.Widget("birth").(*DateEntry).SetText("__/__/2024")
+	if valid || w.ValidationError("birth") == nil {
+		t.Fatal("partial date should be invalid")
+	}
+}
+
+func TestInputFieldsDirty(t *testing.T) {
+	test.NewTempApp(t)
+
+	w := NewInputFields(test.NewWindow(nil))
+	w.AddText("name", false, "Nom", "Bob", 1)
+	w.AddText("nick", true, "Surnom", "Bobby", 1)
+	w.AddCheck("married", false, "Marié", "", false)
+
+	if w.IsDirty() {
+		t.Fatal("form should not be dirty before MarkClean")
+	}
+	w.MarkClean()
+
+	dirty := false
+	w.OnDirtyChanged = func(b bool) { dirty = b }
+
+	w.Write("name", "Robert")
+	w.SetNull("nick", true)
+	if !dirty || len(w.DirtyFields()) != 2 {
+		t.Fatalf("unexpected dirty fields %v", w.DirtyFields())
+	}
+
+	w.Revert("name")
+	if w.Read("name") != "Bob" || len(w.DirtyFields()) != 1 {
+		t.Fatal("name should be reverted")
+	}
+
+	w.RevertAll()
+	if dirty || w.Read("nick") != "Bobby" {
+		t.Fatal("form should be clean after RevertAll")
+	}
+}
+
+func TestInputFieldsRules(t *testing.T) {
+	test.NewTempApp(t)
+
+	w := NewInputFields(test.NewWindow(nil))
+	w.AddRadioGroup("reason", false, "Motif", []string{"A", "B", "Other"}, "A", false)
+	w.AddText("other", false, "Autre motif", "", 1)
+	w.AddText("details", false, "Détails", "", 1)
+
+	w.ShowWhen("other", func(w *InputFields) bool { return w.Read("reason") == "Other" })
+	w.EnableWhen("details", func(w *InputFields) bool { return w.Read("reason") != "A" })
+
+	if w.GetVisible("other") || w.GetStatus("details") {
+		t.Fatal("other should be hidden and details disabled")
+	}
+	if _, ok := w.ReadAll()["other"]; ok {
+		t.Fatal("hidden input should not be in ReadAll")
+	}
+
+	w.Write("reason", "Other")
+	if !w.GetVisible("other") || !w.GetStatus("details") {
+		t.Fatal("other should be visible and details enabled")
+	}
+	if _, ok := w.ReadAll()["other"]; !ok {
+		t.Fatal("visible input should be in ReadAll")
+	}
+
+	// an input hidden when the form is marked clean is still tracked
+	w.Write("reason", "A")
+	w.MarkClean()
+	w.Write("reason", "Other")
+	w.Write("other", "changed")
+	if !w.IsDirty() || !equalStrings(w.DirtyFields(), []FieldID{"reason", "other"}) {
+		t.Fatalf("unexpected dirty fields %v", w.DirtyFields())
+	}
+	w.RevertAll()
+	if w.Read("other") != "" || w.Read("reason") != "A" || w.IsDirty() {
+		t.Fatalf("unexpected reverted values %v %v", w.Read("reason"), w.Read("other"))
+	}
+}
+
+func TestInputFieldsReadOnly(t *testing.T) {
+	test.NewTempApp(t)
+
+	w := NewInputFields(test.NewWindow(nil))
+	w.AddText("name", false, "Nom", "Bob", 1)
+	w.AddCheck("married", true, "Marié", "", false)
+	w.AddRadioGroup("sex", false, "Sexe", []string{"M", "F"}, "M", true)
+	w.SetFieldReadOnly("name", true)
+
+	w.AddCheckGroup("tags", false, "Tags", []string{"a", "b"}, []string{"a"}, true)
+
+	w.SetReadOnly(true)
+	test.Tap(w.Widget("married").(*Check))
+	test.Tap(findTappable(t, w.Widget("sex"), "F"))
+	test.Tap(findTappable(t, w.Widget("tags"), "b"))
+	if w.Read("married") != false || w.Read("sex") != "M" || !equalStrings(w.Read("tags").([]string), []string{"a"}) {
+		t.Fatal("read-only inputs should not change")
+	}
+
+	// documents loaded in a read-only form
+	w.Write("sex", "F")
+	w.Write("tags", []string{"b"})
+	if w.Read("sex") != "F" || !equalStrings(w.Read("tags").([]string), []string{"b"}) {
+		t.Fatalf("read-only inputs should accept writes: %v %v", w.Read("sex"), w.Read("tags"))
+	}
+	test.Tap(findTappable(t, w.Widget("sex"), "M"))
+	if w.Read("sex") != "F" {
+		t.Fatal("read-only inputs should not change")
+	}
+
+	w.SetReadOnly(false)
+	if !w.GetFieldReadOnly("name") || w.GetFieldReadOnly("married") {
+		t.Fatal("only name should stay read-only")
+	}
+	test.Tap(w.Widget("married").(*Check))
+	test.Tap(findTappable(t, w.Widget("sex"), "M"))
+	if w.Read("married") != true || w.Read("sex") != "M" {
+		t.Fatal("married should be checked")
+	}
+
+	w.Write("sex", []string{"F", "M"})
+	if w.Read("sex") != "M" {
+		t.Fatalf("several values should be ignored: %v", w.Read("sex"))
+	}
+	w.Write("sex", []string{"F"})
+	if w.Read("sex") != "F" {
+		t.Fatalf("unexpected value %v", w.Read("sex"))
+	}
+}
+
+func TestInputFieldsSchema(t *testing.T) {
+	test.NewTempApp(t)
+
+	schema := `{"items": [
+		{"kind": "tab", "label": "Général"},
+		{"kind": "title", "text": "Identité", "bold": true, "align": "center"},
+		{"kind": "text", "id": "name", "label": "Nom", "value": "Bob"},
+		{"kind": "password", "id": "pwd", "label": "Mot de passe", "value": "secret"},
+		{"kind": "number", "id": "height", "label": "Taille", "float": true, "value": 1.85},
+		{"kind": "date", "id": "birth", "label": "Naissance", "nullable": true, "null": true},
+		{"kind": "separator"},
+		{"kind": "tab", "label": "Divers"},
+		{"kind": "markdown", "text": "# Divers"},
+		{"kind": "select", "id": "sex", "label": "Sexe", "options": ["M", "F"], "value": "F"},
+		{"kind": "checkgroup", "id": "tags", "label": "Tags", "options": ["a", "b"], "value": ["b"], "readonly": true},
+		{"kind": "button", "id": "go", "label": "", "text": "Go", "importance": "high"}
+	]}`
+
+	w, err := NewInputFieldsFromSchema(test.NewWindow(nil), []byte(schema))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if w.Read("name") != "Bob" || w.Read("pwd") != "secret" || w.Read("height") != 1.85 || w.Read("birth") != nil || w.Read("sex") != "F" || !w.GetFieldReadOnly("tags") {
+		t.Fatalf("unexpected values %v", w.ReadAll())
+	}
+
+	data, err := w.ExportSchema()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(data), "secret") {
+		t.Fatalf("password exported:\n%s", data)
+	}
+	w2, err := NewInputFieldsFromSchema(test.NewWindow(nil), data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	w2.Write("pwd", "secret")
+	if len(w2.Schema().Items) != len(w.Schema().Items) || !valuesEqual(w2.ReadAll(), w.ReadAll()) {
+		t.Fatalf("exported schema differs:\n%s", data)
+	}
+
+	if _, err := NewInputFieldsFromSchema(test.NewWindow(nil), []byte(`{"items": [{"kind": "foo"}]}`)); err == nil {
+		t.Fatal("unknown kind should fail")
+	}
+}
+
+func TestInputFieldsSchemaNumbers(t *testing.T) {
+	test.NewTempApp(t)
+	setNumberFormat(t, LocaleNumberFormat("de-DE")) // schema values don't depend on the locale
+
+	schema := `{"items": [
+		{"kind": "number", "id": "f", "float": true, "value": 2.125},
+		{"kind": "number", "id": "i", "value": 1234},
+		{"kind": "number", "id": "d", "float": true, "decimal": true, "value": "2.125"}
+	]}`
+	w, err := NewInputFieldsFromSchema(test.NewWindow(nil), []byte(schema))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if w.Read("f") != 2.125 || w.Read("i") != 1234 {
+		t.Fatalf("unexpected values %v", w.ReadAll())
+	}
+	if r, _, _ := w.ReadDecimal("d"); r == nil || r.Cmp(big.NewRat(17, 8)) != 0 {
+		t.Fatalf("unexpected decimal %v", r)
+	}
+
+	// number formats are exported
+	w.Widget("f").(*NumEntry).SetFormat(&NumberFormat{Decimal: '.', Grouping: ',', Prefix: "$"})
+	w.Widget("i").(*NumEntry).SetFormat(&NumberFormat{Percent: true})
+	w.Write("f", 1234.5)
+	data, _ := w.ExportSchema()
+	w2, err := NewInputFieldsFromSchema(test.NewWindow(nil), data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	f := w2.Widget("f").(*NumEntry)
+	if f.Format == nil || *f.Format != (NumberFormat{Decimal: '.', Grouping: ',', Prefix: "$"}) || f.Text != "$1,234.5" {
+		t.Fatalf("unexpected format %+v %q\n%s", f.Format, f.Text, data)
+	}
+	if i := w2.Widget("i").(*NumEntry); i.Format == nil || !i.Format.Percent || w2.Read("i") != 1234 {
+		t.Fatalf("unexpected percent format %+v %v", i.Format, w2.Read("i"))
+	}
+}
+
+func TestInputFieldsTyped(t *testing.T) {
+	test.NewTempApp(t)
+
+	w := NewInputFields(test.NewWindow(nil))
+	w.AddNumber("age", true, "Age", "42", false, false)
+	w.AddText("count", false, "Nombre", "12", 1)
+	w.AddDate("birth", false, "Naissance", "15/03/1982")
+	w.AddCheck("married", false, "Marié", "", true)
+	w.AddRadioGroup("sex", false, "Sexe", []string{"M", "F"}, "", false)
+	w.AddCheckGroup("tags", false, "Tags", []string{"a", "b", "c"}, []string{"a", "c"}, false)
+
+	if v, ok, err := w.ReadInt("age"); v != 42 || !ok || err != nil {
+		t.Fatal("ReadInt(age)", v, ok, err)
+	}
+	w.SetNull("age", true)
+	if v, ok, err := w.ReadInt("age"); v != 0 || ok || err != nil {
+		t.Fatal("ReadInt(age) null", v, ok, err)
+	}
+	if v, ok, err := w.ReadInt("count"); v != 12 || !ok || err != nil {
+		t.Fatal("ReadInt(count)", v, ok, err)
+	}
+	if v, ok, err := w.ReadTime("birth"); v.Year() != 1982 || !ok || err != nil {
+		t.Fatal("ReadTime(birth)", v, ok, err)
+	}
+	if _, _, err := w.ReadTime("count"); err == nil {
+		t.Fatal("ReadTime(count) should fail")
+	}
+	if v, ok, err := w.ReadBool("married"); !v || !ok || err != nil {
+		t.Fatal("ReadBool(married)", v, ok, err)
+	}
+	if v := w.Read("sex"); v != "" {
+		t.Fatalf("Read(sex) = %#v", v)
+	}
+	if v, ok, err := w.ReadStrings("tags"); len(v) != 2 || !ok || err != nil {
+		t.Fatal("ReadStrings(tags)", v, ok, err)
+	}
+	if _, _, err := w.ReadString("unknown"); err == nil {
+		t.Fatal("ReadString(unknown) should fail")
+	}
+
+	all := w.ReadAllString()
+	w.WriteAllString(map[FieldID]string{"sex": "F", "tags": "b"})
+	if all["birth"] != "15/03/1982" || w.Read("sex") != "F" || len(w.Read("tags").([]string)) != 1 {
+		t.Fatal("ReadAllString/WriteAllString", all, w.ReadAll())
+	}
+}
+
+func TestInputFieldsBinding(t *testing.T) {
+	test.NewTempApp(t)
+
+	w := NewInputFields(test.NewWindow(nil))
+	w.AddText("name", false, "Nom", "", 1)
+	w.AddNumber("age", false, "Age", "", false, false)
+	w.AddDate("birth", true, "Naissance", "")
+	w.AddCheck("married", false, "Marié", "", false)
+
+	changed := 0
+	w.OnChanged = func(FieldID) { changed++ }
+
+	name := binding.NewString()
+	name.Set("Bob")
+	age := binding.NewFloat()
+	birth := binding.NewUntyped()
+	married := binding.NewBool()
+	for id, data := range map[FieldID]binding.DataItem{"name": name, "age": age, "birth": birth, "married": married} {
+		if err := w.BindField(id, data); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := w.BindField("name", binding.NewBytes()); err == nil {
+		t.Fatal("unsupported binding should fail")
+	}
+	if w.Read("name") != "Bob" || w.Read("birth") != nil {
+		t.Fatalf("unexpected values %v", w.ReadAll())
+	}
+
+	changed = 0
+	age.Set(42)
+	if w.Read("age") != 42 || changed != 1 {
+		t.Fatal("age should be 42", w.Read("age"), changed)
+	}
+
+	w.Write("name", "Robert")
+	w.Write("married", true)
+	if v, _ := name.Get(); v != "Robert" {
+		t.Fatal("name data should be Robert")
+	}
+	if v, _ := married.Get(); !v {
+		t.Fatal("married data should be true")
+	}
+
+	tm := time.Date(1982, 3, 15, 0, 0, 0, 0, time.Local)
+	w.setValue("birth", tm)
+	if v, _ := birth.Get(); !valuesEqual(v, tm) {
+		t.Fatal("birth data should be set", v)
+	}
+	w.SetNull("birth", true)
+	if v, _ := birth.Get(); v != nil {
+		t.Fatal("birth data should be nil", v)
+	}
+
+	w.UnbindField("name")
+	name.Set("Bobby")
+	if w.Read("name") != "Robert" {
+		t.Fatal("unbound field should not change")
+	}
+}
+
+func TestInputFieldsHistory(t *testing.T) {
+	test.NewTempApp(t)
+
+	w := NewInputFields(test.NewWindow(nil))
+	w.AddText("name", false, "Nom", "", 1)
+	w.AddCheck("married", false, "Marié", "", false)
+	w.AddSelect("sex", true, "Sexe", []string{"M", "F"}, "M", false)
+
+	name := w.Widget("name").(*EntryEx)
+	test.Type(name, "Bob") // one typing burst
+	test.Tap(w.Widget("married").(*Check))
+	w.SetNull("sex", true)
+
+	if !w.CanUndo() || w.CanRedo() || len(w.history) != 3 {
+		t.Fatalf("unexpected history %v", w.history)
+	}
+
+	name.TypedShortcut(&fyne.ShortcutUndo{})
+	if w.Read("sex") != "M" {
+		t.Fatal("sex should not be null")
+	}
+	w.Undo()
+	if w.Read("married") != false {
+		t.Fatal("married should be unchecked")
+	}
+	w.Undo()
+	if w.Read("name") != "" || w.CanUndo() {
+		t.Fatal("name should be empty")
+	}
+
+	name.TypedShortcut(&fyne.ShortcutRedo{})
+	if w.Read("name") != "Bob" || !w.CanRedo() {
+		t.Fatal("name should be Bob")
+	}
+
+	w.Write("name", "Robert") // drops the redo entries
+	if w.CanRedo() {
+		t.Fatal("redo should be cleared by a new change")
+	}
+
+	w.ClearHistory()
+	if w.CanUndo() {
+		t.Fatal("history should be empty")
+	}
+}
+
+func TestInputFieldsLayout(t *testing.T) {
+	test.NewTempApp(t)
+
+	w := NewInputFields(test.NewWindow(nil))
+	w.AddTab("A", nil)
+	w.AddText("a1", false, "A1", "", 1)
+	w.AddText("a2", false, "A2", "", 1)
+	w.AddTab("B", nil)
+	w.AddText("b1", false, "B1", "", 1)
+	test.NewWindow(w) // renderer
+
+	w.InsertFieldAfter("a1", func() { w.AddText("a1bis", false, "A1bis", "", 1) })
+	if !equalStrings(w.Inputs(), []FieldID{"a1", "a1bis", "a2", "b1"}) {
+		t.Fatalf("unexpected order %v", w.Inputs())
+	}
+
+	w.MoveField("a2", 1, 0)
+	if !equalStrings(w.Inputs(), []FieldID{"a1", "a1bis", "a2", "b1"}) || !equalStrings(w.fieldsOf(w.tabVBox(1)), []FieldID{"a2", "b1"}) {
+		t.Fatalf("unexpected order %v", w.Inputs())
+	}
+
+	w.RemoveField("a1")
+	w.RemoveTab(1)
+	if !equalStrings(w.Inputs(), []FieldID{"a1bis"}) || len(w.tabs.Items) != 1 {
+		t.Fatalf("unexpected order %v", w.Inputs())
+	}
+
+	w.Clear()
+	if len(w.Inputs()) != 0 || w.tabs != nil {
+		t.Fatal("form should be empty")
+	}
+	w.AddText("c", false, "C", "", 1)
+	if w.content.Objects[0] != w.vbox {
+		t.Fatal("renderer should display the new vbox")
+	}
+
+	w.AddText("c", false, "C2", "", 1)
+	w.AddText("", false, "", "", 1) // _noname_input_2
+	w.RemoveField("c")
+	w.AddText("", false, "", "", 1) // _noname_input_2 is taken
+	if !equalStrings(w.Inputs(), []FieldID{"_noname_input_2", "_noname_input_3"}) {
+		t.Fatalf("duplicate ids should be ignored: %v", w.Inputs())
+	}
+}
+
+func TestInputFieldsEnterNavigation(t *testing.T) {
+	test.NewTempApp(t)
+
+	w := NewInputFields(nil)
+	w.AddTab("A", nil)
+	w.AddText("name", false, "Nom", "", 1)
+	w.AddText("nick", true, "Surnom", "", 1)
+	w.AddText("notes", false, "Notes", "", 3)
+	w.AddTab("B", nil)
+	w.AddNumber("age", false, "Age", "", false, false)
+	w.win = test.NewWindow(w)
+	w.EnterNavigation = true
+
+	submitted := false
+	w.OnSubmit = func() { submitted = true }
+	w.SetNull("nick", true)
+
+	w.SetFocus("name")
+	w.Widget("name").(*EntryEx).TypedKey(&fyne.KeyEvent{Name: fyne.KeyReturn})
+	if w.focusedField() != "notes" {
+		t.Fatal("null nick should be skipped")
+	}
+
+	w.Widget("notes").(*EntryEx).TypedKey(&fyne.KeyEvent{Name: fyne.KeyReturn})
+	if w.focusedField() != "notes" {
+		t.Fatal("Enter should insert a new line in multiline entries")
+	}
+
+	w.SetFocus("name")
+	w.SetStatus("notes", false)
+	w.Widget("name").(*EntryEx).TypedKey(&fyne.KeyEvent{Name: fyne.KeyReturn})
+	if w.focusedField() != "age" || w.tabs.SelectedIndex() != 1 {
+		t.Fatal("focus should move to age in tab B")
+	}
+
+	w.Widget("age").(*NumEntry).TypedKey(&fyne.KeyEvent{Name: fyne.KeyReturn})
+	if !submitted {
+		t.Fatal("Enter in the last input should submit")
+	}
+}
+
+func TestInputFieldsColumns(t *testing.T) {
+	test.NewTempApp(t)
+
+	w := NewInputFields(test.NewWindow(nil))
+	w.ResponsiveWidth = 300
+	w.SetColumns(2)
+	w.AddText("a", false, "A", "", 1)
+	w.AddText("b", false, "B", "", 1)
+	w.AddText("c", false, "C", "", 1)
+	w.SetFieldSpan("c", 2)
+	w.AddSection("S", true)
+	w.AddText("d", false, "D", "", 1)
+	w.EndSection()
+	w.AddText("e", false, "E", "", 1)
+	if !equalStrings(w.Inputs(), []FieldID{"a", "b", "c", "d", "e"}) {
+		t.Fatalf("unexpected order %v", w.Inputs())
+	}
+
+	win := test.NewWindow(w)
+	win.Resize(fyne.NewSize(800, 600))
+	a, b, c := w.inputs["a"].cell, w.inputs["b"].cell, w.inputs["c"].cell
+	if a.Position().Y != b.Position().Y || b.Position().X <= a.Position().X {
+		t.Fatal("a and b should be on the same row")
+	}
+	if c.Position().Y <= a.Position().Y || c.Size().Width <= b.Position().X+b.Size().Width-a.Position().X-1 {
+		t.Fatal("c should span the whole row")
+	}
+
+	win.Resize(fyne.NewSize(250, 600))
+	if b.Position().Y <= a.Position().Y {
+		t.Fatal("responsive mode should use one column")
+	}
+	if w.columnsChanged {
+		t.Fatal("the content should be refreshed after the column change")
+	}
+
+	data, _ := w.ExportSchema()
+	w2, err := NewInputFieldsFromSchema(test.NewWindow(nil), data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if data2, _ := w2.ExportSchema(); string(data2) != string(data) {
+		t.Fatalf("exported schema differs:\n%s\n%s", data, data2)
+	}
+}
+
+func TestInputFieldsRepeater(t *testing.T) {
+	test.NewTempApp(t)
+
+	w := NewInputFields(test.NewWindow(nil))
+	w.AddRepeater("phones", true, "Téléphones", func(sub *InputFields) {
+		sub.AddText("num", false, "Numéro", "", 1)
+		sub.AddValidator("num", ValidateRequired())
+		sub.AddCheck("main", false, "", "Principal", false)
+	})
+	changes := 0
+	w.OnChanged = func(_ FieldID) { changes++ }
+
+	r := w.Widget("phones").(*Repeater)
+	if v := w.Read("phones"); v == nil || len(v.([]map[string]any)) != 0 {
+		t.Fatalf("unexpected value %v", v)
+	}
+
+	w.Write("phones", []map[string]any{{"num": "0102", "main": true}, {"num": "0304"}})
+	if r.Len() != 2 || changes != 1 || !w.Valid() {
+		t.Fatalf("unexpected rows %d (changes %d)", r.Len(), changes)
+	}
+
+	w.ClearHistory()
+	r.Row(1).Write("num", "")
+	if changes != 2 || w.Valid() {
+		t.Fatal("an invalid row should invalidate the form")
+	}
+
+	r.MoveRow(1, 0)
+	r.RemoveRow(0)
+	want := []map[string]any{{"num": "0102", "main": true}}
+	if !valuesEqual(w.Read("phones"), want) {
+		t.Fatalf("unexpected value %v", w.Read("phones"))
+	}
+
+	w.Undo()
+	if v := w.Read("phones").([]map[string]any); len(v) != 2 || v[1]["num"] != "0304" {
+		t.Fatalf("unexpected value after undo %v", v)
+	}
+
+	w.SetNull("phones", true)
+	if w.Read("phones") != nil || !r.Disabled() {
+		t.Fatal("repeater should be null")
+	}
+
+	w.SetNull("phones", false)
+	data, err := w.ExportSchema()
+	if err != nil {
+		t.Fatal(err)
+	}
+	w2, err := NewInputFieldsFromSchema(test.NewWindow(nil), data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !valuesEqual(w2.Read("phones"), w.Read("phones")) {
+		t.Fatalf("unexpected value from schema %v", w2.Read("phones"))
+	}
+}
+
+func TestInputFieldsMultiText(t *testing.T) {
+	test.NewTempApp(t)
+
+	w := NewInputFields(test.NewWindow(nil))
+	w.AddMultiText("mails", false, "E-mails", []string{"a@b.c"}, []string{"x@y.z"})
+	changes := 0
+	w.OnChanged = func(_ FieldID) { changes++ }
+
+	m := w.Widget("mails").(*MultiEntry)
+	m.MinItems, m.MaxItems = 2, 3
+	test.NewWindow(w) // renderer
+	if m.Len() != 2 || !equalStrings(w.Read("mails").([]string), []string{"a@b.c"}) {
+		t.Fatalf("unexpected rows %d %v", m.Len(), w.Read("mails"))
+	}
+
+	test.Type(m.Entry(1), "d@e.f")
+	if changes == 0 || !equalStrings(w.Read("mails").([]string), []string{"a@b.c", "d@e.f"}) {
+		t.Fatalf("unexpected value %v", w.Read("mails"))
+	}
+
+	n := changes
+	m.MoveRow(1, 0)
+	m.RemoveRow(1)
+	if changes != n+1 || m.Len() != 2 || !equalStrings(w.Read("mails").([]string), []string{"d@e.f", "a@b.c"}) {
+		t.Fatalf("rows should not be removed below MinItems: %v (%d rows)", w.Read("mails"), m.Len())
+	}
+	m.MinItems = 1
+	m.RemoveRow(1)
+	if changes != n+2 || !equalStrings(w.Read("mails").([]string), []string{"d@e.f"}) {
+		t.Fatalf("unexpected value %v", w.Read("mails"))
+	}
+
+	w.SetReadOnly(true)
+	test.Type(m.Entry(0), "x")
+	if !equalStrings(w.Read("mails").([]string), []string{"d@e.f"}) {
+		t.Fatal("read-only multi text should not change")
+	}
+
+	m.SetValues([]string{"1", "2", "3", "4"})
+	if m.Len() != 3 {
+		t.Fatalf("values beyond MaxItems should be dropped: %v", m.Values())
+	}
+
+	m = NewMultiEntry(nil, nil)
+	m.Options = []string{"x@y.z", "a@b.c"}
+	m.MinItems = 1
+	test.NewTempWindow(t, m)
+	test.Type(m.Entry(0), "X")
+	if !m.Entry(0).ListVisible() || m.dataLength() != 1 {
+		t.Fatalf("matching options should be listed: %v", m.matches)
+	}
+	test.Type(m.Entry(0), "q")
+	if m.Entry(0).ListVisible() {
+		t.Fatal("list should be hidden without matching options")
+	}
+}
+
+func TestInputFieldsDecimal(t *testing.T) {
+	test.NewTempApp(t)
+
+	w := NewInputFields(test.NewWindow(nil))
+	w.AddNumber("amount", false, "Amount", "", true, false, NumberOptions{Decimal: true, Decimals: 2})
+	w.Write("amount", big.NewRat(1999, 100))
+
+	r, ok := w.Read("amount").(*big.Rat)
+	if !ok || r.Cmp(big.NewRat(1999, 100)) != 0 {
+		t.Fatalf("unexpected value %v", w.Read("amount"))
+	}
+
+	w.Write("amount", 0.1)
+	if r, _, _ := w.ReadDecimal("amount"); r.Cmp(big.NewRat(1, 10)) != 0 {
+		t.Fatalf("unexpected value %v", r)
+	}
+
+	w.Write("amount", (*big.Rat)(nil))
+	if s, _, _ := w.ReadString("amount"); s != "" {
+		t.Fatalf("nil should empty the input: %s", s)
+	}
+}
+
+func TestInputFieldsDateRange(t *testing.T) {
+	test.NewTempApp(t)
+
+	w := NewInputFields(test.NewWindow(nil))
+	w.AddDateRange("period", true, "Period", "01/01/2024", "31/01/2024")
+	from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.Local)
+	if v, ok := w.Read("period").([2]time.Time); !ok || !v[0].Equal(from) {
+		t.Fatalf("unexpected value %v", w.Read("period"))
+	}
+
+	w.WriteString("period", "01/02/2024 - 29/02/2024")
+	if s, _, _ := w.ReadString("period"); s != "01/02/2024 - 29/02/2024" {
+		t.Fatalf("unexpected text %s", s)
+	}
+
+	schema, err := w.ExportSchema()
+	if err != nil {
+		t.Fatal(err)
+	}
+	w2, err := NewInputFieldsFromSchema(test.NewWindow(nil), schema)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s, _, _ := w2.ReadString("period"); s != "01/02/2024 - 29/02/2024" {
+		t.Fatalf("unexpected schema value %s", s)
+	}
+	if v := w.Schema().Items[0].Value; v != "2024-02-01/2024-02-29" {
+		t.Fatalf("unexpected exported value %v", v)
+	}
+
+	// schema dates don't depend on the date format
+	DefaultDateFormat = DateFormatMDY
+	defer func() { DefaultDateFormat = DateFormatDMY }()
+	w2, err = NewInputFieldsFromSchema(test.NewWindow(nil), schema)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s, _, _ := w2.ReadString("period"); s != "02/01/2024 - 02/29/2024" {
+		t.Fatalf("unexpected schema value %s", s)
+	}
+}
+
+func TestInputFieldsDateTime(t *testing.T) {
+	test.NewTempApp(t)
+
+	w := NewInputFields(test.NewWindow(nil))
+	w.AddTime("start", false, "Start", "08:15")
+	w.AddDateTime("meeting", true, "Meeting", "01/02/2024 14:00", time.UTC)
+	if v, ok := w.Read("meeting").(time.Time); !ok || !v.Equal(time.Date(2024, 2, 1, 14, 0, 0, 0, time.UTC)) {
+		t.Fatalf("unexpected value %v", w.Read("meeting"))
+	}
+
+	w.Write("start", time.Date(0, 1, 1, 17, 45, 0, 0, time.UTC))
+	if s, _, _ := w.ReadString("start"); s != "17:45" {
+		t.Fatalf("unexpected text %s", s)
+	}
+
+	schema, err := w.ExportSchema()
+	if err != nil {
+		t.Fatal(err)
+	}
+	w2, err := NewInputFieldsFromSchema(test.NewWindow(nil), schema)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s, _, _ := w2.ReadString("meeting"); s != "01/02/2024 14:00" {
+		t.Fatalf("unexpected schema value %s", s)
+	}
+	if w2.Widget("meeting").(*DateTimeEntry).Location != time.UTC {
+		t.Fatal("location not exported")
+	}
+
+	w.Widget("start").(*TimeEntry).SetFormat(&TimeFormat{Seconds: true, Hour12: true})
+	w.Write("start", time.Date(0, 1, 1, 17, 45, 30, 0, time.UTC))
+	w.Widget("meeting").(*DateTimeEntry).SetDateFormat(&DateFormatISO)
+	s := w.Schema()
+	if s.Items[0].Value != "17:45:30" || s.Items[1].Value != "2024-02-01T14:00:00" {
+		t.Fatalf("unexpected exported values %v, %v", s.Items[0].Value, s.Items[1].Value)
+	}
+	schema, _ = w.ExportSchema()
+	if w2, err = NewInputFieldsFromSchema(test.NewWindow(nil), schema); err != nil {
+		t.Fatal(err)
+	}
+	if s, _, _ := w2.ReadString("start"); s != "05:45:30 PM" {
+		t.Fatalf("unexpected time %s", s)
+	}
+	if s, _, _ := w2.ReadString("meeting"); s != "2024-02-01 14:00" {
+		t.Fatalf("unexpected date and time %s", s)
+	}
+
+	// texts are read in the format of the item
+	w2, err = NewInputFieldsFromSchema(test.NewWindow(nil), []byte(`{"items": [
+		{"kind": "date", "id": "d", "dateformat": "01/02/2006", "value": "12/31/2024"},
+		{"kind": "time", "id": "t", "timeformat": "03:04 PM", "value": "05:45 PM"},
+		{"kind": "daterange", "id": "r", "dateformat": "2006-01-02", "value": "2024-12-01 - 2024-12-31"}
+	]}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v := w2.Read("d"); v != time.Date(2024, 12, 31, 0, 0, 0, 0, time.Local) {
+		t.Fatalf("unexpected date %v", v)
+	}
+	if s, _, _ := w2.ReadString("t"); s != "05:45 PM" {
+		t.Fatalf("unexpected time %s", s)
+	}
+	if s, _, _ := w2.ReadString("r"); s != "2024-12-01 - 2024-12-31" {
+		t.Fatalf("unexpected range %s", s)
+	}
+}
+
+// findTappable returns the tappable child of o displaying text (e.g. an item of a radio or check group).
+func findTappable(t *testing.T, o fyne.CanvasObject, text string) fyne.Tappable {
+	t.Helper()
+	var found fyne.Tappable
+	var walk func(o fyne.CanvasObject, tap fyne.Tappable)
+	walk = func(o fyne.CanvasObject, tap fyne.Tappable) {
+		if tp, ok := o.(fyne.Tappable); ok {
+			tap = tp
+		}
+		switch o := o.(type) {
+		case *canvas.Text:
+			if o.Text == text && found == nil {
+				found = tap
+			}
+		case *fyne.Container:
+			for _, c := range o.Objects {
+				walk(c, tap)
+			}
+		case fyne.Widget:
+			for _, c := range test.WidgetRenderer(o).Objects() {
+				walk(c, tap)
+			}
+		}
+	}
+	walk(o, nil)
+	if found == nil {
+		t.Fatalf("no tappable %q", text)
+	}
+	return found
+}
@@ -0,0 +1,264 @@
+package wx
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/lang"
+	"fyne.io/fyne/v2/theme"
+)
+
+// Validator checks the value of an input (as returned by InputFields.Read).
+//
+// An empty input (empty text, empty NumEntry or DateEntry, unchecked Check, no selection...)
+// is passed as nil, so that only ValidateRequired rejects empty inputs.
+type Validator func(value any) error
+
+// AddValidator registers validators for an input. They are called in order, the first error is kept.
+//
+// Inputs are validated each time they change (the error is then displayed under the input),
+// and when calling Validate. Null inputs (unchecked nullable check) are not validated.
+func (w *InputFields) AddValidator(id FieldID, validators ...Validator) {
+	if f, ok := w.inputs[id]; ok {
+		f.validators = append(f.validators, validators...)
+		w.updateValidity()
+	}
+}
+
+// Validate validates all the inputs, displays the errors under the offending inputs,
+// and returns the errors by FieldID (empty map if the form is valid).
+func (w *InputFields) Validate() (errs map[FieldID]error) {
+	errs = make(map[FieldID]error)
+	for _, id := range w.order {
+		f := w.inputs[id]
+		if r, ok := f.Widget.(*Repeater); ok && !f.hidden && (f.check == nil || f.check.Checked) {
+			r.validateRows()
+		}
+		if err := w.validateField(id); err != nil {
+			errs[id] = err
+			w.showError(f, err)
+		} else {
+			w.showError(f, nil)
+		}
+	}
+	w.updateValidity()
+	return
+}
+
+// Valid returns wether all the inputs are valid, without displaying the errors.
+func (w *InputFields) Valid() bool {
+	for _, id := range w.order {
+		if w.validateField(id) != nil {
+			return false
+		}
+	}
+	return true
+}
+
+// ValidationError returns the error currently displayed for an input.
+func (w *InputFields) ValidationError(id FieldID) error {
+	if f, ok := w.inputs[id]; ok {
+		return f.err
+	}
+	return nil
+}
+
+// ClearValidation hides all the displayed errors (e.g. after loading a new document).
+func (w *InputFields) ClearValidation() {
+	for _, f := range w.inputs {
+		w.showError(f, nil)
+	}
+}
+
+// ----------------------------------------------------------------------------
+// Validators
+
+// ValidateRequired rejects empty inputs.
+func ValidateRequired() Validator {
+	return func(value any) error {
+		if value == nil {
+			return errors.New(lang.L("This field is required"))
+		}
+		return nil
+	}
+}
+
+// ValidateMinLength rejects texts shorter than n characters (or lists with less than n items/rows).
+func ValidateMinLength(n int) Validator {
+	return func(value any) error {
+		if l, ok := valueLength(value); ok && l < n {
+			return errors.New(lang.L("Minimum {{.N}} characters", map[string]any{"N": n}))
+		}
+		return nil
+	}
+}
+
+// ValidateMaxLength rejects texts longer than n characters (or lists with more than n items/rows).
+func ValidateMaxLength(n int) Validator {
+	return func(value any) error {
+		if l, ok := valueLength(value); ok && l > n {
+			return errors.New(lang.L("Maximum {{.N}} characters", map[string]any{"N": n}))
+		}
+		return nil
+	}
+}
+
+// ValidateRegexp rejects texts that don't match expr. msg is the error message (a generic one is used if empty).
+//
+// It panics if expr cannot be compiled.
+func ValidateRegexp(expr string, msg string) Validator {
+	re := regexp.MustCompile(expr)
+	return func(value any) error {
+		if s, ok := value.(string); ok && !re.MatchString(s) {
+			if msg == "" {
+				return errors.New(lang.L("Invalid format"))
+			}
+			return errors.New(msg)
+		}
+		return nil
+	}
+}
+
+// ValidateRange rejects numbers outside of [min, max].
+func ValidateRange(min, max float64) Validator {
+	return func(value any) error {
+		if f, ok := numberValue(value); ok && (f < min || f > max) {
+			return errors.New(lang.L("Value must be between {{.Min}} and {{.Max}}", map[string]any{"Min": min, "Max": max}))
+		}
+		return nil
+	}
+}
+
+// ValidateDateRange rejects dates outside of [min, max]. A zero min or max is not checked.
+func ValidateDateRange(min, max time.Time) Validator {
+	layout := DefaultDateFormat.Layout()
+	return func(value any) error {
+		t, ok := value.(time.Time)
+		if !ok {
+			return nil
+		}
+		if !min.IsZero() && t.Before(min) {
+			return errors.New(lang.L("Date must be after {{.Date}}", map[string]any{"Date": min.Format(layout)}))
+		}
+		if !max.IsZero() && t.After(max) {
+			return errors.New(lang.L("Date must be before {{.Date}}", map[string]any{"Date": max.Format(layout)}))
+		}
+		return nil
+	}
+}
+
+// ----------------------------------------------------------------------------
+// internals
+
+func (w *InputFields) hasValidation(f *inputField) bool {
+	if len(f.validators) > 0 || f.err != nil {
+		return true
+	}
+	_, ok := f.Widget.(fyne.Validatable)
+	return ok
+}
+
+func (w *InputFields) revalidate(id FieldID) {
+	if f := w.inputs[id]; f != nil && w.hasValidation(f) {
+		w.showError(f, w.validateField(id))
+		w.updateValidity()
+	}
+}
+
+func (w *InputFields) validateField(id FieldID) error {
+	f := w.inputs[id]
+	if f == nil || f.hidden || (f.check != nil && !f.check.Checked) {
+		return nil
+	}
+
+	if v, ok := f.Widget.(fyne.Validatable); ok {
+		if err := v.Validate(); err != nil {
+			return err
+		}
+	}
+
+	value := w.Read(id)
+	if w.isEmpty(f, value) {
+		value = nil
+	}
+	for _, v := range f.validators {
+		if err := v(value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (w *InputFields) isEmpty(f *inputField, value any) bool {
+	switch wid := f.Widget.(type) {
+	case *NumEntry:
+		return strings.Trim(wid.GetText(), "+-") == ""
+	case *DateEntry:
+		return wid.GetText() == ""
+	case *TimeEntry:
+		return wid.GetText() == ""
+	case *DateTimeEntry:
+		return wid.GetText() == ""
+	case *DateRangeEntry:
+		return wid.GetText() == ""
+	}
+
+	switch v := value.(type) {
+	case nil:
+		return true
+	case string:
+		return v == ""
+	case []string:
+		return len(v) == 0
+	case []map[string]any:
+		return len(v) == 0
+	case bool:
+		return !v
+	case time.Time:
+		return v.IsZero()
+	}
+	return false
+}
+
+func (w *InputFields) showError(f *inputField, err error) {
+	if f.err == nil && err == nil {
+		return
+	}
+	f.err = err
+
+	if err == nil {
+		f.errBorder.Hide()
+		f.errLabel.Hide()
+	} else {
+		f.errBorder.StrokeColor = theme.Color(theme.ColorNameError)
+		f.errBorder.Show()
+		f.errLabel.SetText(err.Error())
+		f.errLabel.Show()
+	}
+	f.cell.Refresh()
+}
+
+func (w *InputFields) updateValidity() {
+	if valid := w.Valid(); valid != w.valid {
+		w.valid = valid
+		if w.OnValidationChanged != nil {
+			w.OnValidationChanged(valid)
+		}
+	}
+}
+
+func valueLength(value any) (l int, ok bool) {
+	switch v := value.(type) {
+	case string:
+		return utf8.RuneCountInString(v), true
+	case []string:
+		return len(v), true
+	case []map[string]any:
+		return len(v), true
+	}
+	return
+}
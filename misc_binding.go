@@ -0,0 +1,54 @@
+package wx
+
+import (
+	"time"
+
+	"fyne.io/fyne/v2/data/binding"
+)
+
+// NewTimeBinding returns a bindable time.Time value (see DateEntry.BindTime).
+func NewTimeBinding() binding.Item[time.Time] {
+	return binding.NewItem(time.Time.Equal)
+}
+
+// dataBinder connects a widget to a data item.
+//
+// Widget changes caused by the data are not written back to it, and data changes
+// caused by the widget are not applied back to the widget (no echo loops).
+type dataBinder struct {
+	data     binding.DataItem
+	listener binding.DataListener
+	updating bool
+}
+
+// bind connects data, fromData is called (on the main goroutine) when the data changes.
+func (b *dataBinder) bind(data binding.DataItem, fromData func()) {
+	b.unbind()
+	b.data = data
+	b.listener = binding.NewDataListener(func() {
+		if b.updating {
+			return
+		}
+		b.updating = true
+		defer func() { b.updating = false }()
+		fromData()
+	})
+	data.AddListener(b.listener)
+}
+
+func (b *dataBinder) unbind() {
+	if b.data != nil {
+		b.data.RemoveListener(b.listener)
+	}
+	b.data, b.listener = nil, nil
+}
+
+// toData is called when the widget changes, set is not called if the change comes from the data.
+func (b *dataBinder) toData(set func()) {
+	if b.data == nil || b.updating {
+		return
+	}
+	b.updating = true
+	defer func() { b.updating = false }()
+	set()
+}
@@ -1,6 +1,316 @@
 package wx
 
-// TODO implémenter un widget qui comporte plusieurs entry dans une VBox
-//      avec bouton ajouter, supprimer, réorganiser
-//		pourquoi pas autocomplete associé
-//		
\ No newline at end of file
+import (
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/lang"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+)
+
+// MultiEntry is an editable list of entries (e.g. e-mail addresses or keywords),
+// with add, remove and reorder buttons.
+//
+// Each row is an AutoComplete, using the Options/CustomXxx callbacks of the MultiEntry
+// (see AutoComplete).
+type MultiEntry struct {
+	widget.DisableableWidget
+
+	OnChanged func([]string)
+
+	MinItems int // minimum number of rows (rows can't be removed below it)
+	MaxItems int // maximum number of rows (0 for no limit)
+
+	// auto-completion, shared by all the rows (see AutoComplete)
+	Options        []string
+	CustomLength   func() int
+	CustomCreate   func() fyne.CanvasObject
+	CustomUpdate   func(id int, co fyne.CanvasObject)
+	CustomComplete func(id int) (ret string, close bool)
+
+	// Called when the text of a row is edited, typically to update Options and call ac.ListShow().
+	// If nil, the Options starting with the text are shown when a row is edited (Custom lists are not shown).
+	OnTextChanged func(ac *AutoComplete, text string)
+
+	// custom callbacks, forwarded from the rows
+	OnTypedKey      func(*fyne.KeyEvent) (block bool)
+	OnTypedShortcut func(fyne.Shortcut) (block bool)
+
+	rows     []*multiEntryRow
+	box      *fyne.Container
+	addBtn   *widget.Button
+	values   []string // last values passed to OnChanged
+	matches  []string // Options starting with the text of the edited row, if OnTextChanged is nil
+	readOnly bool
+}
+
+type multiEntryRow struct {
+	entry         *AutoComplete
+	obj           fyne.CanvasObject
+	up, down, del *widget.Button
+	buttons       *fyne.Container
+}
+
+func NewMultiEntry(values []string, changed func([]string)) *MultiEntry {
+	m := &MultiEntry{box: container.NewVBox()}
+	m.addBtn = widget.NewButtonWithIcon(lang.L("Add"), theme.ContentAddIcon(), m.addRow)
+	m.ExtendBaseWidget(m)
+	m.SetValues(values)
+	m.OnChanged = changed
+	return m
+}
+
+func (m *MultiEntry) CreateRenderer() fyne.WidgetRenderer {
+	m.update()
+	return widget.NewSimpleRenderer(container.NewVBox(m.box, container.NewHBox(m.addBtn)))
+}
+
+// Values returns the texts of the rows, empty rows excluded.
+func (m *MultiEntry) Values() []string {
+	ret := make([]string, 0, len(m.rows))
+	for _, row := range m.rows {
+		if row.entry.Text != "" {
+			ret = append(ret, row.entry.Text)
+		}
+	}
+	return ret
+}
+
+// SetValues replaces the rows (at least MinItems rows are displayed, values beyond MaxItems are dropped),
+// and calls OnChanged if the values changed.
+func (m *MultiEntry) SetValues(values []string) {
+	if m.MaxItems > 0 && len(values) > m.MaxItems {
+		values = values[:m.MaxItems]
+	}
+	m.rows = nil
+	for _, s := range values {
+		m.rows = append(m.rows, m.newRow(s))
+	}
+	m.update()
+	m.changed()
+}
+
+// Len returns the number of rows (including empty rows).
+func (m *MultiEntry) Len() int { return len(m.rows) }
+
+// Entry returns the entry of a row (nil if out of range).
+func (m *MultiEntry) Entry(i int) *AutoComplete {
+	if i < 0 || i >= len(m.rows) {
+		return nil
+	}
+	return m.rows[i].entry
+}
+
+// RemoveRow removes a row (if there are more than MinItems rows).
+func (m *MultiEntry) RemoveRow(i int) {
+	if i < 0 || i >= len(m.rows) || len(m.rows) <= m.MinItems {
+		return
+	}
+	m.rows = append(m.rows[:i], m.rows[i+1:]...)
+	m.update()
+	m.changed()
+}
+
+// MoveRow moves the row i to the index j.
+func (m *MultiEntry) MoveRow(i, j int) {
+	if i < 0 || i >= len(m.rows) || j < 0 || j >= len(m.rows) || i == j {
+		return
+	}
+	row := m.rows[i]
+	m.rows = append(m.rows[:i], m.rows[i+1:]...)
+	m.rows = append(m.rows[:j], append([]*multiEntryRow{row}, m.rows[j:]...)...)
+	m.update()
+	m.changed()
+}
+
+// ----------------------------------------------------------------------------
+// Disableable / ReadOnlyable
+
+func (m *MultiEntry) Enable() {
+	m.DisableableWidget.Enable()
+	m.update()
+}
+
+func (m *MultiEntry) Disable() {
+	m.DisableableWidget.Disable()
+	m.update()
+}
+
+// ReadOnly returns read-only status.
+//
+// Read-Only widget will display like a normal widget, but it will be impossible to modify the rows.
+func (m *MultiEntry) ReadOnly() bool { return m.readOnly }
+
+// SetReadOnly sets read-only status.
+//
+// Read-Only widget will display like a normal widget, but it will be impossible to modify the rows.
+func (m *MultiEntry) SetReadOnly(b bool) {
+	m.readOnly = b
+	for _, row := range m.rows {
+		row.entry.SetReadOnly(b)
+	}
+	m.update()
+}
+
+// ----------------------------------------------------------------------------
+// internals
+
+// addRow appends an empty row and focuses it.
+func (m *MultiEntry) addRow() {
+	if m.MaxItems > 0 && len(m.rows) >= m.MaxItems {
+		return
+	}
+	row := m.newRow("")
+	m.rows = append(m.rows, row)
+	m.update()
+	if cnv := fyne.CurrentApp().Driver().CanvasForObject(m); cnv != nil {
+		cnv.Focus(row.entry)
+	}
+}
+
+func (m *MultiEntry) newRow(text string) *multiEntryRow {
+	row := &multiEntryRow{entry: NewAutoComplete(1)}
+	ac := row.entry
+	ac.SetText(text)
+	ac.CustomLength = m.dataLength
+	ac.CustomCreate = m.dataCreate
+	ac.CustomUpdate = func(id int, co fyne.CanvasObject) { m.dataUpdate(ac, id, co) }
+	ac.CustomComplete = m.dataComplete
+	ac.OnChanged = func(s string) { m.rowChanged(ac, s) }
+	ac.OnTypedKey = func(ke *fyne.KeyEvent) bool { return m.OnTypedKey != nil && m.OnTypedKey(ke) }
+	ac.OnTypedShortcut = func(s fyne.Shortcut) bool { return m.OnTypedShortcut != nil && m.OnTypedShortcut(s) }
+	if m.readOnly {
+		ac.SetReadOnly(true)
+	}
+
+	row.up = widget.NewButtonWithIcon("", theme.MoveUpIcon(), func() { m.moveRow(row, -1) })
+	row.down = widget.NewButtonWithIcon("", theme.MoveDownIcon(), func() { m.moveRow(row, 1) })
+	row.del = widget.NewButtonWithIcon("", theme.DeleteIcon(), func() { m.RemoveRow(m.indexRow(row)) })
+	row.up.Importance, row.down.Importance, row.del.Importance = widget.LowImportance, widget.LowImportance, widget.LowImportance
+	row.buttons = container.NewHBox(row.up, row.down, row.del)
+	row.obj = container.NewBorder(nil, nil, nil, row.buttons, ac)
+	return row
+}
+
+func (m *MultiEntry) rowChanged(ac *AutoComplete, s string) {
+	if m.OnTextChanged != nil {
+		m.OnTextChanged(ac, s)
+	} else if m.CustomLength == nil {
+		m.matches = m.matches[:0]
+		for _, o := range m.Options {
+			if s != "" && o != s && strings.HasPrefix(strings.ToLower(o), strings.ToLower(s)) {
+				m.matches = append(m.matches, o)
+			}
+		}
+		if len(m.matches) > 0 {
+			ac.ListShow()
+		} else {
+			ac.ListHide()
+		}
+	}
+	m.changed()
+}
+
+func (m *MultiEntry) changed() {
+	values := m.Values()
+	if m.values != nil && equalStrings(values, m.values) {
+		return
+	}
+	m.values = values
+	if m.OnChanged != nil {
+		m.OnChanged(values)
+	}
+}
+
+func (m *MultiEntry) indexRow(row *multiEntryRow) int {
+	for i := range m.rows {
+		if m.rows[i] == row {
+			return i
+		}
+	}
+	return -1
+}
+
+func (m *MultiEntry) moveRow(row *multiEntryRow, delta int) {
+	if i := m.indexRow(row); i >= 0 {
+		m.MoveRow(i, i+delta)
+	}
+}
+
+// update rebuilds the rows list (adding empty rows up to MinItems) and updates the state of the rows and buttons.
+func (m *MultiEntry) update() {
+	for len(m.rows) < m.MinItems {
+		m.rows = append(m.rows, m.newRow(""))
+	}
+	disabled := m.Disabled()
+
+	objects := make([]fyne.CanvasObject, len(m.rows))
+	for i, row := range m.rows {
+		objects[i] = row.obj
+
+		if disabled != row.entry.Disabled() {
+			setEnabled(row.entry, !disabled)
+		}
+		setEnabled(row.up, !disabled && i > 0)
+		setEnabled(row.down, !disabled && i < len(m.rows)-1)
+		setEnabled(row.del, !disabled && len(m.rows) > m.MinItems)
+		if m.readOnly {
+			row.buttons.Hide()
+		} else {
+			row.buttons.Show()
+		}
+	}
+	m.box.Objects = objects
+	m.box.Refresh()
+
+	setEnabled(m.addBtn, !disabled && (m.MaxItems <= 0 || len(m.rows) < m.MaxItems))
+	if m.readOnly {
+		m.addBtn.Hide()
+	} else {
+		m.addBtn.Show()
+	}
+}
+
+// auto-completion data (see AutoComplete.data_xxx)
+
+func (m *MultiEntry) dataLength() int {
+	if m.CustomLength == nil {
+		return len(m.options())
+	}
+	return m.CustomLength()
+}
+
+func (m *MultiEntry) dataCreate() fyne.CanvasObject {
+	if m.CustomCreate == nil {
+		return &widget.Label{}
+	}
+	return m.CustomCreate()
+}
+
+func (m *MultiEntry) dataUpdate(ac *AutoComplete, id int, co fyne.CanvasObject) {
+	if m.CustomUpdate == nil {
+		co.(*widget.Label).SetText(m.options()[id])
+		if ac.list != nil {
+			ac.list.SetItemHeight(id, co.MinSize().Height)
+		}
+	} else {
+		m.CustomUpdate(id, co)
+	}
+}
+
+func (m *MultiEntry) dataComplete(id int) (ret string, close bool) {
+	if m.CustomComplete == nil {
+		return m.options()[id], true
+	}
+	return m.CustomComplete(id)
+}
+
+// options returns the items of the auto-completion list (see OnTextChanged).
+func (m *MultiEntry) options() []string {
+	if m.OnTextChanged == nil {
+		return m.matches
+	}
+	return m.Options
+}
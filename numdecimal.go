@@ -0,0 +1,59 @@
+package wx
+
+import (
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// RoundingMode is the rounding of exact decimal values to a number of fraction digits (see NumEntry.Decimal).
+type RoundingMode int
+
+const (
+	RoundHalfUp   RoundingMode = iota // 2.5 → 3, -2.5 → -3 (half away from zero)
+	RoundHalfEven                     // 2.5 → 2, 3.5 → 4 (banker's rounding)
+	RoundDown                         // toward zero (truncation)
+	RoundUp                           // away from zero
+)
+
+// roundRat rounds r to scale fraction digits.
+func roundRat(r *big.Rat, scale int, mode RoundingMode) *big.Rat {
+	pow := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(scale)), nil)
+	num := new(big.Int).Mul(r.Num(), pow)
+	den := r.Denom()
+
+	q, m := new(big.Int).QuoRem(num, den, new(big.Int)) // truncated toward zero
+	if m.Sign() != 0 {
+		away := false
+		switch mode {
+		case RoundUp:
+			away = true
+		case RoundHalfUp, RoundHalfEven:
+			c := new(big.Int).Mul(new(big.Int).Abs(m), big.NewInt(2)).Cmp(den)
+			away = c > 0 || (c == 0 && (mode == RoundHalfUp || q.Bit(0) == 1))
+		}
+		if away {
+			q.Add(q, big.NewInt(int64(num.Sign())))
+		}
+	}
+	return new(big.Rat).SetFrac(q, pow)
+}
+
+// ratString returns the decimal representation of r, without trailing zeros
+// (rounded to 20 fraction digits if r has no finite representation, e.g. 1/3).
+func ratString(r *big.Rat) string {
+	if r.IsInt() {
+		return r.Num().String()
+	}
+	s := strings.TrimRight(r.FloatString(20), "0")
+	return strings.TrimSuffix(s, ".")
+}
+
+// ratFromFloat returns the decimal value of the shortest representation of f (0.1 is 1/10).
+func ratFromFloat(f float64) *big.Rat {
+	r, _ := new(big.Rat).SetString(strconv.FormatFloat(f, 'f', -1, 64))
+	if r == nil {
+		r = new(big.Rat)
+	}
+	return r
+}
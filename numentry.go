@@ -1,13 +1,17 @@
 package wx
 
 import (
+	"errors"
 	"fmt"
 	"math"
+	"math/big"
 	"strconv"
 	"strings"
 
 	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/data/binding"
 	"fyne.io/fyne/v2/driver/desktop"
+	"fyne.io/fyne/v2/lang"
 	"fyne.io/fyne/v2/theme"
 	"fyne.io/fyne/v2/widget"
 )
@@ -18,6 +22,29 @@ type NumEntry struct {
 	Float  bool
 	Signed bool
 
+	Decimal  bool         // exact decimal values (see GetDecimal), for money amounts
+	Rounding RoundingMode // rounding of decimal values to Decimals fraction digits
+
+	Format *NumberFormat // separators, prefix/suffix, percent (DefaultNumberFormat if nil, see SetFormat)
+
+	// bounds of the value (no bounds if Min == Max), typed values are checked on FocusLost
+	Min          float64
+	Max          float64
+	Reject       bool                // out of range values are rejected (the value before focus is restored) instead of being clamped
+	OnOutOfRange func(value float64) // called on FocusLost with the out of range value, before it is clamped or rejected
+
+	Decimals int // fixed number of fraction digits of float and decimal entries (0 for any)
+
+	// stepping, with the spinner, the Up/Down keys and the mouse wheel (when focused)
+	Step    float64 // 1 if 0
+	Wrap    bool    // stepping past Max goes to Min, and conversely
+	Spinner bool    // shows an UpDownButton as ActionItem (must be set before the entry is displayed)
+
+	// Calculator accepts arithmetic expressions (+ - * / parentheses, and % to divide by 100, e.g. "12,5*3+7"),
+	// evaluated on Enter and FocusLost. The formula is then displayed as tooltip (see Formula).
+	// An invalid expression is kept as typed, and reported by Validate.
+	Calculator bool
+
 	OnChanged func(string)
 
 	lastValidInt int
@@ -26,6 +53,9 @@ type NumEntry struct {
 	lastValidFloat float64
 	OnChangedFloat func(float64)
 
+	lastValidDecimal *big.Rat
+	OnChangedDecimal func(*big.Rat)
+
 	ToolTipable
 
 	// custom callbacks
@@ -37,16 +67,39 @@ type NumEntry struct {
 
 	readOnly bool
 	minCols  int
+	spinner  *UpDownButton
+	focusVal string // text when the focus was gained (see Reject)
+
+	focused   bool
+	decorated bool   // Entry.Text is the displayed text (see NumberFormat)
+	edit      string // typed text when decorated
+
+	formula             string            // last evaluated expression
+	toolTip             fyne.CanvasObject // ToolTip replaced by the formula
+	exprErr             error
+	evaluating          bool
+	onValidationChanged func(error)
+
+	binder dataBinder
 }
 
 func NewNumEntry() *NumEntry {
 	n := &NumEntry{}
+	n.Scroll = fyne.ScrollNone // no inner widget.Scroll, which would catch the mouse wheel (see Scrolled)
+	n.ToolTipable.parent = n
 	n.ExtendBaseWidget(n)
 	n.Entry.OnChanged = func(s string) {
+		if !n.evaluating {
+			n.clearFormula()
+			n.checkExpr()
+		}
 		if n.OnChanged != nil {
 			n.OnChanged(s)
 		}
-		if !n.Float && n.OnChangedInt != nil {
+		if n.Calculator && isExpr(n.GetText()) {
+			return // the value is updated when the expression is evaluated
+		}
+		if !n.fractional() && n.OnChangedInt != nil {
 			i := n.GetInt()
 			if i != n.lastValidInt {
 				n.OnChangedInt(i)
@@ -60,10 +113,123 @@ func NewNumEntry() *NumEntry {
 				n.lastValidFloat = f
 			}
 		}
+		if n.Decimal && n.OnChangedDecimal != nil {
+			d := n.GetDecimal()
+			if n.lastValidDecimal == nil || d.Cmp(n.lastValidDecimal) != 0 {
+				if n.lastValidDecimal != nil || d.Sign() != 0 {
+					n.OnChangedDecimal(d)
+				}
+				n.lastValidDecimal = d
+			}
+		}
+		n.binder.toData(func() {
+			switch data := n.binder.data.(type) {
+			case binding.Int:
+				data.Set(int(n.GetFloat()))
+			case binding.Float:
+				data.Set(n.GetFloat())
+			}
+		})
 	}
 	return n
 }
 
+// BindInt connects the entry to an int data source.
+// Changes of the data update the entry, and typed values are written to the data.
+func (n *NumEntry) BindInt(data binding.Int) {
+	n.binder.bind(data, func() {
+		if i, err := data.Get(); err == nil && i != int(n.GetFloat()) {
+			n.SetInt(i)
+		}
+	})
+}
+
+// BindFloat connects the entry to a float data source.
+// Changes of the data update the entry, and typed values are written to the data.
+func (n *NumEntry) BindFloat(data binding.Float) {
+	n.binder.bind(data, func() {
+		if f, err := data.Get(); err == nil && f != n.GetFloat() {
+			n.SetFloat(f)
+		}
+	})
+}
+
+// Unbind disconnects the data source set by BindInt or BindFloat.
+// The entry keeps its current value.
+func (n *NumEntry) Unbind() {
+	n.binder.unbind()
+}
+
+func (n *NumEntry) CreateRenderer() fyne.WidgetRenderer {
+	if n.Spinner && n.ActionItem == nil {
+		n.spinner = NewUpDownButton(n.StepUp, n.StepDown)
+		if n.Disabled() {
+			n.spinner.Disable()
+		}
+		n.ActionItem = n.spinner
+	}
+	return n.Entry.CreateRenderer()
+}
+
+func (n *NumEntry) Enable() {
+	n.Entry.Enable()
+	if n.spinner != nil {
+		n.spinner.Enable()
+	}
+}
+
+func (n *NumEntry) Disable() {
+	n.Entry.Disable()
+	if n.spinner != nil {
+		n.spinner.Disable()
+	}
+}
+
+// StepUp adds Step to the value (see Min, Max and Wrap).
+func (n *NumEntry) StepUp() { n.step(1) }
+
+// StepDown subtracts Step from the value (see Min, Max and Wrap).
+func (n *NumEntry) StepDown() { n.step(-1) }
+
+// Formula returns the last evaluated expression, if the value has not been edited since (see Calculator).
+func (n *NumEntry) Formula() string { return n.formula }
+
+// Validate returns an error if the text is an invalid expression (see Calculator),
+// then calls the Entry Validator if any.
+func (n *NumEntry) Validate() error {
+	if n.exprErr != nil {
+		return n.exprErr
+	}
+	return n.Entry.Validate()
+}
+
+// SetOnValidationChanged is intended for parent widgets or containers to hook into the validation.
+func (n *NumEntry) SetOnValidationChanged(callback func(error)) {
+	n.onValidationChanged = callback
+	n.Entry.SetOnValidationChanged(callback)
+}
+
+// SetFormat changes the number format (nil for DefaultNumberFormat), the value is kept.
+func (n *NumEntry) SetFormat(f *NumberFormat) {
+	n.undecorate()
+	old := n.format().decimal()
+	n.Format = f
+	if dec := n.format().decimal(); dec != old {
+		n.Entry.Text = strings.Replace(n.Entry.Text, string(old), string(dec), 1)
+	}
+	n.decorate()
+	n.Entry.Refresh()
+}
+
+// GetText returns the typed text, without the grouping separators, prefix and suffix
+// displayed when the entry is not focused.
+func (n *NumEntry) GetText() string {
+	if n.decorated {
+		return n.edit
+	}
+	return n.Entry.Text
+}
+
 func (n *NumEntry) ReadOnly() bool { return n.readOnly }
 func (n *NumEntry) SetReadOnly(b bool) {
 	n.readOnly = b
@@ -86,25 +252,24 @@ func (e *NumEntry) MinSize() fyne.Size {
 	return sz
 }
 
+// SetText sets the value from a text, the separators of other locales are accepted (see NumberFormat).
 func (n *NumEntry) SetText(s string) {
-	n.Entry.SetText("")
-	if s == "" {
-		return
-	}
+	n.undecorate()
+	old, readOnly := n.Entry.OnChanged, n.readOnly
+	n.Entry.OnChanged, n.readOnly = nil, false
 
-	old := n.Entry.OnChanged
-	n.Entry.OnChanged = nil
-
-	runes := []rune(s)
-	for i := 0; i < len(runes); i++ {
-		n.TypedRune(runes[i])
+	n.Entry.SetText("")
+	for _, r := range n.format().normalize(s) {
+		n.TypedRune(r)
 	}
 
-	n.Entry.OnChanged = old
+	n.Entry.OnChanged, n.readOnly = old, readOnly
 	n.Entry.OnChanged(n.Entry.Text)
+	n.decorate()
 }
 
 func (n *NumEntry) SetInt(i int) {
+	n.undecorate()
 	if i == 0 {
 		n.Entry.SetText("")
 	}
@@ -112,24 +277,60 @@ func (n *NumEntry) SetInt(i int) {
 	n.Entry.CursorColumn = len(n.Entry.Text)
 	n.Entry.Refresh()
 	n.Entry.OnChanged(n.Entry.Text)
+	n.decorate()
 }
 
 func (n *NumEntry) SetFloat(f float64) {
+	n.undecorate()
 	if f == 0 {
 		n.Entry.SetText("")
 	}
-	n.Entry.Text = strings.Replace(fmt.Sprint(f), ".", ",", 1)
+	n.Entry.Text = n.formatFloat(f)
 	n.Entry.CursorColumn = len(n.Entry.Text)
 	n.Entry.Refresh()
 	n.Entry.OnChanged(n.Entry.Text)
+	n.decorate()
+}
+
+// SetDecimal sets an exact value, rounded to Decimals fraction digits (see Rounding), nil empties the entry.
+func (n *NumEntry) SetDecimal(r *big.Rat) {
+	if r == nil {
+		n.SetText("")
+		return
+	}
+	n.undecorate()
+	if r.Sign() == 0 {
+		n.Entry.SetText("")
+	}
+	n.Entry.Text = n.formatDecimal(r)
+	n.Entry.CursorColumn = len(n.Entry.Text)
+	n.Entry.Refresh()
+	n.Entry.OnChanged(n.Entry.Text)
+	n.decorate()
+}
+
+// GetDecimal returns the exact value (0 if empty).
+func (n *NumEntry) GetDecimal() *big.Rat {
+	s := strings.Replace(n.GetText(), string(n.format().decimal()), ".", 1)
+	r, ok := new(big.Rat).SetString(strings.TrimSuffix(s, "."))
+	if !ok {
+		return new(big.Rat)
+	}
+	if n.percent() {
+		r.Quo(r, big.NewRat(100, 1))
+	}
+	return r
 }
 
 func (n *NumEntry) GetInt() int {
-	return int(math.Abs(n.GetFloat()))
+	return int(n.GetFloat())
 }
 
 func (n *NumEntry) GetFloat() float64 {
-	f, _ := strconv.ParseFloat(strings.Replace(n.Entry.Text, ",", ".", 1), 64)
+	f, _ := strconv.ParseFloat(strings.Replace(n.GetText(), string(n.format().decimal()), ".", 1), 64)
+	if n.percent() {
+		f = math.Round(f*1e7) / 1e9
+	}
 	return f
 }
 
@@ -143,43 +344,88 @@ func (n *NumEntry) TypedRune(r rune) {
 		if n.fnHasSign() {
 			n.Entry.CursorColumn = 1
 		}
+		if n.fnFullDecimals() {
+			return
+		}
 		if n.OnTypedRune != nil && n.OnTypedRune(r) {
 			return
 		}
 		n.Entry.TypedRune(r)
 	// ---
 	case '.', ',':
-		if n.Float && !strings.Contains(n.Entry.Text, ",") {
+		if dec := n.format().decimal(); n.fractional() && !strings.ContainsRune(n.fnNumberAtCursor(), dec) {
 			if n.fnHasSign() {
 				n.Entry.CursorColumn = 1
 			}
 			if n.OnTypedRune != nil && n.OnTypedRune(r) {
 				return
 			}
-			n.Entry.TypedRune(',')
+			n.Entry.TypedRune(dec)
+		}
+	// ---
+	case '*', '/', '(', ')', '%':
+		if n.Calculator {
+			if n.OnTypedRune != nil && n.OnTypedRune(r) {
+				return
+			}
+			n.Entry.TypedRune(r)
 		}
 	// ---
 	case '+', '-':
-		if n.Signed {
+		if n.Calculator && n.Entry.CursorColumn > 0 {
+			if n.OnTypedRune != nil && n.OnTypedRune(r) {
+				return
+			}
+			n.Entry.TypedRune(r)
+		} else if n.Signed {
 			n.updateSign(r)
-			if n.OnChanged != nil {
-				n.OnChanged(n.Entry.Text)
+			if n.Entry.OnChanged != nil {
+				n.Entry.OnChanged(n.Entry.Text)
 			}
 		}
 	}
 }
 
 func (n *NumEntry) TypedKey(ke *fyne.KeyEvent) {
+	if ke.Name == fyne.KeyReturn || ke.Name == fyne.KeyEnter {
+		n.evaluate()
+	}
 	if n.OnTypedKey != nil && n.OnTypedKey(ke) {
 		return
 	}
-	n.Entry.TypedKey(ke)
+	switch ke.Name {
+	case fyne.KeyUp:
+		n.StepUp()
+	case fyne.KeyDown:
+		n.StepDown()
+	default:
+		n.Entry.TypedKey(ke)
+	}
+}
+
+// Scrolled steps the value when the entry is focused.
+func (n *NumEntry) Scrolled(se *fyne.ScrollEvent) {
+	if cnv := fyne.CurrentApp().Driver().CanvasForObject(n); cnv == nil || cnv.Focused() != n {
+		return
+	}
+	if se.Scrolled.DY > 0 {
+		n.StepUp()
+	} else if se.Scrolled.DY < 0 {
+		n.StepDown()
+	}
 }
 
 func (n *NumEntry) FocusGained() {
 	if n.readOnly {
 		return
 	}
+	n.focused = true
+	if n.decorated {
+		n.undecorate()
+		n.Entry.CursorColumn = len(n.Entry.Text)
+		n.Entry.Refresh()
+	}
+	n.focusVal = n.Entry.Text
 	n.Entry.FocusGained()
 	if n.OnFocusGained != nil {
 		n.OnFocusGained()
@@ -187,6 +433,11 @@ func (n *NumEntry) FocusGained() {
 }
 
 func (n *NumEntry) FocusLost() {
+	if !n.readOnly && n.evaluate() {
+		n.checkValue()
+	}
+	n.focused = false
+	n.decorate()
 	n.Entry.FocusLost()
 	if n.OnFocusLost != nil {
 		n.OnFocusLost()
@@ -202,7 +453,7 @@ func (n *NumEntry) TypedShortcut(s fyne.Shortcut) {
 		if n.readOnly {
 			return
 		}
-		for _, r := range s.Clipboard.Content() {
+		for _, r := range n.format().normalize(s.Clipboard.Content()) {
 			n.TypedRune(r)
 		}
 	default:
@@ -214,19 +465,257 @@ func (n *NumEntry) MouseIn(me *desktop.MouseEvent)    { n.ToolTipable.MouseIn(me
 func (n *NumEntry) MouseMoved(me *desktop.MouseEvent) { n.ToolTipable.MouseMoved(me) }
 func (n *NumEntry) MouseOut()                         { n.ToolTipable.MouseOut() }
 
-func (n *NumEntry) updateSign(r rune) {
-	if len(n.Text) > 0 {
-		if n.Text[0] == '+' || n.Text[0] == '-' {
-			n.Entry.SetText(string(r) + n.Text[1:])
-		} else {
-			n.Entry.SetText(string(r) + n.Text)
-			n.Entry.CursorColumn += 1
+// step is computed with exact decimals, so that float steps (e.g. 0.1) don't accumulate errors.
+func (n *NumEntry) step(dir int64) {
+	if n.readOnly || n.Disabled() {
+		return
+	}
+
+	step := n.Step
+	if step == 0 && n.percent() {
+		step = 0.01
+	} else if step == 0 {
+		step = 1
+	}
+	v := new(big.Rat).Mul(big.NewRat(dir, 1), ratFromFloat(step))
+	v.Add(v, n.GetDecimal())
+
+	if n.Min != n.Max {
+		min, max := ratFromFloat(n.Min), ratFromFloat(n.Max)
+		if v.Cmp(max) > 0 {
+			if n.Wrap {
+				v = min
+			} else {
+				v = max
+			}
+		} else if v.Cmp(min) < 0 {
+			if n.Wrap {
+				v = max
+			} else {
+				v = min
+			}
+		}
+	}
+	if !n.Signed && v.Sign() < 0 {
+		v = new(big.Rat)
+	}
+	n.setValue(v)
+}
+
+// evaluate replaces an expression by its value (see Calculator), and returns false if it is invalid.
+func (n *NumEntry) evaluate() bool {
+	s := n.GetText()
+	if !n.Calculator || n.readOnly || !isExpr(s) {
+		return true
+	}
+
+	r, err := n.evalExpr(s)
+	if err != nil {
+		if n.exprErr == nil || n.exprErr.Error() != err.Error() {
+			n.setExprErr(err)
+		}
+		return false
+	}
+
+	if n.percent() {
+		r.Quo(r, big.NewRat(100, 1))
+	}
+	n.evaluating = true
+	n.clearFormula()
+	if n.exprErr != nil {
+		n.setExprErr(nil)
+	}
+	n.toolTip = n.ToolTip
+	n.formula = s
+	n.SetToolTip("", s, nil)
+	n.setValue(r)
+	n.evaluating = false
+	return true
+}
+
+func (n *NumEntry) evalExpr(s string) (*big.Rat, error) {
+	r, err := evalExpr(s, n.format().decimal())
+	if err == nil && !n.Signed && r.Sign() < 0 {
+		err = errors.New(lang.L("Value must be positive"))
+	}
+	return r, err
+}
+
+// clearFormula restores the tooltip replaced by the formula.
+func (n *NumEntry) clearFormula() {
+	if n.formula != "" {
+		n.ToolTip = n.toolTip
+		n.formula, n.toolTip = "", nil
+	}
+}
+
+// checkExpr updates the expression error after an edit, it is kept while the text is an invalid expression.
+func (n *NumEntry) checkExpr() {
+	if n.exprErr == nil {
+		return
+	}
+	var err error
+	if s := n.GetText(); n.Calculator && isExpr(s) {
+		_, err = n.evalExpr(s)
+	}
+	if err == nil || err.Error() != n.exprErr.Error() {
+		n.setExprErr(err)
+	}
+}
+
+func (n *NumEntry) setExprErr(err error) {
+	n.exprErr = err
+	if n.onValidationChanged != nil {
+		n.onValidationChanged(err)
+	}
+}
+
+// checkValue clamps (or rejects) out of range values, and applies Decimals.
+func (n *NumEntry) checkValue() {
+	if strings.Trim(n.GetText(), "+-") == "" {
+		return
+	}
+
+	v := n.GetDecimal()
+	if n.Min != n.Max {
+		min, max := ratFromFloat(n.Min), ratFromFloat(n.Max)
+		if v.Cmp(min) < 0 || v.Cmp(max) > 0 {
+			if n.OnOutOfRange != nil {
+				f, _ := v.Float64()
+				n.OnOutOfRange(f)
+			}
+			if n.Reject {
+				n.SetText(n.focusVal)
+				return
+			}
+			if v.Cmp(min) < 0 {
+				n.setValue(min)
+			} else {
+				n.setValue(max)
+			}
+			return
+		}
+	}
+	if n.fractional() && n.Decimals > 0 {
+		n.setValue(v)
+	}
+}
+
+// setValue sets a value with the setter of the entry mode, if the typed text changes.
+func (n *NumEntry) setValue(v *big.Rat) {
+	switch {
+	case n.Decimal:
+		if n.formatDecimal(v) != n.GetText() {
+			n.SetDecimal(v)
+		}
+	case n.Float:
+		if f, _ := v.Float64(); n.formatFloat(f) != n.GetText() {
+			n.SetFloat(f)
+		}
+	default:
+		if i := int(roundRat(v, 0, RoundHalfUp).Num().Int64()); strconv.Itoa(i) != n.GetText() {
+			n.SetInt(i)
 		}
+	}
+}
+
+// formatDecimal returns the typed text of an exact value.
+func (n *NumEntry) formatDecimal(r *big.Rat) string {
+	if n.percent() {
+		r = new(big.Rat).Mul(r, big.NewRat(100, 1))
+	}
+	var s string
+	if n.Decimals > 0 {
+		s = roundRat(r, n.Decimals, n.Rounding).FloatString(n.Decimals)
 	} else {
-		n.Entry.Text = string(r)
-		n.Entry.CursorColumn = 1
-		n.Entry.Refresh()
+		s = ratString(r)
+	}
+	return strings.Replace(s, ".", string(n.format().decimal()), 1)
+}
+
+// formatFloat returns the typed text of a value.
+func (n *NumEntry) formatFloat(f float64) string {
+	if n.percent() {
+		f = math.Round(f*1e11) / 1e9
+	}
+	dec := string(n.format().decimal())
+	if n.Decimals > 0 {
+		return strings.Replace(strconv.FormatFloat(f, 'f', n.Decimals, 64), ".", dec, 1)
+	}
+	return strings.Replace(fmt.Sprint(f), ".", dec, 1)
+}
+
+func (n *NumEntry) format() NumberFormat {
+	if n.Format != nil {
+		return *n.Format
+	}
+	return DefaultNumberFormat
+}
+
+func (n *NumEntry) percent() bool {
+	return n.fractional() && n.format().Percent
+}
+
+func (n *NumEntry) fractional() bool {
+	return n.Float || n.Decimal
+}
+
+// decorate displays the grouping separators, prefix and suffix when the entry is not focused.
+func (n *NumEntry) decorate() {
+	f := n.format()
+	if n.focused || n.decorated || !f.decorated() || n.Entry.Text == "" || isExpr(n.Entry.Text) {
+		return
+	}
+	n.edit = n.Entry.Text
+	n.Entry.Text = f.display(n.edit)
+	n.decorated = true
+	n.Entry.Refresh()
+}
+
+// undecorate restores the typed text (without refreshing).
+func (n *NumEntry) undecorate() {
+	if n.decorated {
+		n.Entry.Text = n.edit
+		n.decorated = false
+	}
+}
+
+func (n *NumEntry) updateSign(r rune) {
+	if len(n.Text) > 0 && (n.Text[0] == '+' || n.Text[0] == '-') {
+		n.Entry.Text = string(r) + n.Text[1:]
+	} else {
+		n.Entry.Text = string(r) + n.Text
+		n.Entry.CursorColumn += 1
+	}
+	n.Entry.Refresh()
+}
+
+// fnFullDecimals returns wether a digit typed at the cursor would exceed Decimals.
+func (n *NumEntry) fnFullDecimals() bool {
+	if !n.fractional() || n.Decimals <= 0 || (n.Calculator && isExpr(n.Entry.Text)) {
+		return false
+	}
+	i := strings.IndexRune(n.Entry.Text, n.format().decimal())
+	return i >= 0 && n.Entry.CursorColumn > i && len(n.Entry.Text)-i-1 >= n.Decimals
+}
+
+// fnNumberAtCursor returns the number typed at the cursor (the whole text, or an operand of an expression).
+func (n *NumEntry) fnNumberAtCursor() string {
+	if !n.Calculator {
+		return n.Entry.Text
+	}
+	text := []rune(n.Entry.Text)
+	start, end := n.Entry.CursorColumn, n.Entry.CursorColumn
+	if start > len(text) {
+		start, end = len(text), len(text)
+	}
+	for start > 0 && !strings.ContainsRune("+-*/()%", text[start-1]) {
+		start--
+	}
+	for end < len(text) && !strings.ContainsRune("+-*/()%", text[end]) {
+		end++
 	}
+	return string(text[start:end])
 }
 
 func (n *NumEntry) fnHasSign() bool {
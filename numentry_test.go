@@ -1,11 +1,22 @@
 package wx
 
 import (
+	"math/big"
 	"testing"
 
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/data/binding"
+	"fyne.io/fyne/v2/lang"
 	"fyne.io/fyne/v2/test"
 )
 
+// setNumberFormat sets DefaultNumberFormat during the test (the default depends on the system locale).
+func setNumberFormat(t *testing.T, f NumberFormat) {
+	old := DefaultNumberFormat
+	DefaultNumberFormat = f
+	t.Cleanup(func() { DefaultNumberFormat = old })
+}
+
 func TestNumEntryType(t *testing.T) {
 	n := NewNumEntry()
 	test.Type(n, "some text with number in it 123\nother line without numbers...")
@@ -57,6 +68,7 @@ func TestNumEntrySetInt(t *testing.T) {
 }
 
 func TestNumEntryOnChanged(t *testing.T) {
+	setNumberFormat(t, NumberFormat{Decimal: ','})
 	n := NewNumEntry()
 	n.Float = true
 	n.Signed = true
@@ -136,3 +148,273 @@ func TestNumEntryOnChanged(t *testing.T) {
 	chkFlt = -30.5
 	n.SetFloat(-30.5)
 }
+
+func TestNumEntryBind(t *testing.T) {
+	test.NewTempApp(t)
+
+	n := NewNumEntry()
+	n.Signed = true
+	calls := 0
+	n.OnChangedInt = func(int) { calls++ }
+
+	data := binding.NewInt()
+	data.Set(12)
+	n.BindInt(data)
+	if n.Text != "12" {
+		t.Fatal("n.Text != 12")
+	}
+
+	test.Type(n, "3")
+	if v, _ := data.Get(); v != 123 {
+		t.Fatalf("data != 123 (%d)", v)
+	}
+
+	data.Set(-5)
+	if n.Text != "-5" || calls != 3 {
+		t.Fatalf("n.Text != -5 (%q, %d calls)", n.Text, calls)
+	}
+
+	n.Unbind()
+	data.Set(7)
+	if n.Text != "-5" {
+		t.Fatal("unbound entry should not change")
+	}
+}
+
+func TestNumEntryStep(t *testing.T) {
+	test.NewTempApp(t)
+
+	n := NewNumEntry()
+	n.Spinner = true
+	n.Min, n.Max = 0, 10
+	n.Step = 4
+	test.NewWindow(n)
+
+	n.StepUp()
+	n.TypedKey(&fyne.KeyEvent{Name: fyne.KeyUp})
+	n.StepUp()
+	if n.GetInt() != 10 {
+		t.Fatalf("value should be clamped to Max: %s", n.Text)
+	}
+
+	n.Wrap = true
+	n.StepUp()
+	if n.GetInt() != 0 {
+		t.Fatalf("value should wrap to Min: %s", n.Text)
+	}
+
+	n.Float, n.Step = true, 0.1
+	n.SetFloat(0.2)
+	n.spinner.OnUp()
+	if n.GetFloat() != 0.3 {
+		t.Fatalf("unexpected float step: %s", n.Text)
+	}
+
+	n.Disable()
+	n.TypedKey(&fyne.KeyEvent{Name: fyne.KeyDown})
+	if n.GetFloat() != 0.3 || !n.spinner.Disabled() {
+		t.Fatal("disabled entry should not step")
+	}
+}
+
+func TestNumEntryScroll(t *testing.T) {
+	test.NewTempApp(t)
+
+	n := NewNumEntry()
+	w := test.NewWindow(n)
+	w.Resize(fyne.NewSize(200, 50))
+	pos := fyne.NewPos(n.Size().Width/2, n.Size().Height/2)
+
+	test.Scroll(w.Canvas(), pos, 0, 10)
+	if n.Text != "" {
+		t.Fatalf("unfocused entry should not step: %s", n.Text)
+	}
+
+	w.Canvas().Focus(n)
+	test.Scroll(w.Canvas(), pos, 0, 10)
+	test.Scroll(w.Canvas(), pos, 0, 10)
+	test.Scroll(w.Canvas(), pos, 0, -10)
+	if n.Text != "1" {
+		t.Fatalf("the mouse wheel should step the value: %s", n.Text)
+	}
+}
+
+func TestNumEntryRange(t *testing.T) {
+	test.NewTempApp(t)
+	setNumberFormat(t, NumberFormat{Decimal: ','})
+
+	n := NewNumEntry()
+	n.Signed = true
+	test.Type(n, "-42")
+	if n.GetInt() != -42 {
+		t.Fatalf("n.GetInt() != -42: %d", n.GetInt())
+	}
+
+	var out float64
+	n.Min, n.Max = -10, 10
+	n.OnOutOfRange = func(v float64) { out = v }
+	n.FocusGained()
+	n.FocusLost()
+	if out != -42 || n.GetInt() != -10 {
+		t.Fatalf("value should be clamped: %s", n.Text)
+	}
+
+	n.Reject = true
+	n.FocusGained()
+	test.Type(n, "5")
+	n.FocusLost()
+	if n.GetInt() != -10 {
+		t.Fatalf("value should be rejected: %s", n.Text)
+	}
+
+	n = NewNumEntry()
+	n.Float, n.Decimals = true, 2
+	test.Type(n, "1,2345")
+	if n.Text != "1,23" {
+		t.Fatalf("unexpected text %s", n.Text)
+	}
+	n.SetText("3")
+	n.FocusLost()
+	if n.Text != "3,00" {
+		t.Fatalf("unexpected text %s", n.Text)
+	}
+}
+
+func TestLocaleNumberFormat(t *testing.T) {
+	for l, want := range map[fyne.Locale]NumberFormat{
+		"en-US": {Decimal: '.', Grouping: ','},
+		"fr_FR": {Decimal: ',', Grouping: '\u00a0'},
+		"de-DE": {Decimal: ',', Grouping: '.'},
+		"de-CH": {Decimal: '.', Grouping: '\''},
+	} {
+		if f := LocaleNumberFormat(l); f != want {
+			t.Errorf("unexpected format of %s: %+v", l, f)
+		}
+	}
+
+	f := DefaultNumberFormat
+	if f.Decimal != LocaleNumberFormat(lang.SystemLocale()).Decimal || f.decorated() {
+		t.Fatalf("default format should use the locale decimal separator, without grouping: %+v", f)
+	}
+}
+
+func TestNumEntryFormat(t *testing.T) {
+	test.NewTempApp(t)
+
+	en := LocaleNumberFormat("en-US")
+	en.Prefix = "$"
+	n := NewNumEntry()
+	n.Float, n.Signed, n.Format = true, true, &en
+	n.SetText("-1234567,5") // french separator
+	if n.GetFloat() != -1234567.5 || n.Text != "$-1,234,567.5" || n.GetText() != "-1234567.5" {
+		t.Fatalf("unexpected value %v %q", n.GetFloat(), n.Text)
+	}
+	n.FocusGained()
+	if n.Text != "-1234567.5" {
+		t.Fatalf("focused entry should display the typed text: %q", n.Text)
+	}
+	n.FocusLost()
+
+	fr := LocaleNumberFormat("fr")
+	n.SetFormat(&fr)
+	if n.Text != "-1\u00a0234\u00a0567,5" {
+		t.Fatalf("unexpected text %q", n.Text)
+	}
+	n.SetText("1.234") // grouping separator of another locale
+	if n.GetFloat() != 1.234 {
+		t.Fatalf("unexpected value %v", n.GetFloat())
+	}
+
+	n.SetFormat(&NumberFormat{Decimal: ',', Percent: true})
+	n.SetFloat(0.255)
+	if n.Text != "25,5 %" || n.GetFloat() != 0.255 {
+		t.Fatalf("unexpected percent %q %v", n.Text, n.GetFloat())
+	}
+}
+
+func TestNumEntryDecimal(t *testing.T) {
+	test.NewTempApp(t)
+	setNumberFormat(t, NumberFormat{Decimal: ','})
+
+	var got *big.Rat
+	n := NewNumEntry()
+	n.Decimal = true
+	n.OnChangedDecimal = func(r *big.Rat) { got = r }
+	n.Step = 0.1
+	n.StepUp()
+	n.StepUp()
+	n.StepUp()
+	if n.Text != "0,3" || got == nil || got.Cmp(big.NewRat(3, 10)) != 0 {
+		t.Fatalf("unexpected value %s (%v)", n.Text, got)
+	}
+
+	n.Decimals = 2
+	n.Rounding = RoundHalfEven
+	n.SetDecimal(big.NewRat(12345, 1000))
+	if n.Text != "12,34" {
+		t.Fatalf("unexpected text %s", n.Text)
+	}
+	n.Rounding = RoundHalfUp
+	n.SetDecimal(big.NewRat(12345, 1000))
+	if n.Text != "12,35" {
+		t.Fatalf("unexpected text %s", n.Text)
+	}
+
+	test.Type(n, "1") // fraction digits are full
+	if n.Text != "12,35" {
+		t.Fatalf("typed digit should be rejected: %s", n.Text)
+	}
+	n.SetText("0,10")
+	if n.GetDecimal().Cmp(big.NewRat(1, 10)) != 0 {
+		t.Fatalf("unexpected value %v", n.GetDecimal())
+	}
+
+	n.SetDecimal(nil)
+	if n.Text != "" {
+		t.Fatalf("nil should empty the entry: %s", n.Text)
+	}
+}
+
+func TestNumEntryCalculator(t *testing.T) {
+	test.NewTempApp(t)
+	setNumberFormat(t, NumberFormat{Decimal: ','})
+
+	var got float64
+	n := NewNumEntry()
+	n.Float, n.Calculator = true, true
+	n.OnChangedFloat = func(f float64) { got = f }
+	test.Type(n, "12,5*3+7")
+	if n.Text != "12,5*3+7" || got != 12.5 {
+		t.Fatalf("unexpected text %s (%v)", n.Text, got)
+	}
+	n.TypedKey(&fyne.KeyEvent{Name: fyne.KeyReturn})
+	if n.Text != "44,5" || got != 44.5 || n.Formula() != "12,5*3+7" {
+		t.Fatalf("unexpected value %s (%v)", n.Text, got)
+	}
+
+	n.SetText("")
+	test.Type(n, "200*(1+15%)")
+	n.FocusLost()
+	if n.GetFloat() != 230 || n.Formula() == "" {
+		t.Fatalf("unexpected value %s", n.Text)
+	}
+	test.Type(n, "1")
+	if n.Formula() != "" {
+		t.Fatal("formula should be cleared")
+	}
+
+	n.SetText("")
+	test.Type(n, "5/(2-2)")
+	n.FocusLost()
+	if n.Text != "5/(2-2)" || n.Validate() == nil {
+		t.Fatalf("expression should be invalid: %s", n.Text)
+	}
+	test.Type(n, "1")
+	if n.Validate() == nil {
+		t.Fatalf("expression should still be invalid: %s", n.Text)
+	}
+	n.SetText("5/(2-1)")
+	if n.Validate() != nil {
+		t.Fatal("error should be cleared")
+	}
+}
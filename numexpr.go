@@ -0,0 +1,133 @@
+package wx
+
+import (
+	"errors"
+	"math/big"
+	"strings"
+
+	"fyne.io/fyne/v2/lang"
+)
+
+// evalExpr evaluates an arithmetic expression (see NumEntry.Calculator), with exact decimals.
+//
+//	expr    = term { ("+" | "-") term }
+//	term    = unary { ("*" | "/") unary }
+//	unary   = { "+" | "-" } postfix
+//	postfix = primary { "%" }            // 15% is 0.15
+//	primary = number | "(" expr ")"
+//
+// Numbers use dec or '.' as decimal separator.
+func evalExpr(s string, dec rune) (*big.Rat, error) {
+	p := &exprParser{s: []rune(strings.ReplaceAll(s, " ", "")), dec: dec}
+	r, err := p.expr()
+	if err == nil && p.i < len(p.s) {
+		err = errInvalidExpr()
+	}
+	return r, err
+}
+
+// isExpr returns wether s is an expression rather than a signed number.
+func isExpr(s string) bool {
+	return strings.ContainsAny(strings.TrimLeft(s, "+-"), "+-*/()%")
+}
+
+func errInvalidExpr() error { return errors.New(lang.L("Invalid expression")) }
+
+type exprParser struct {
+	s   []rune
+	i   int
+	dec rune
+}
+
+func (p *exprParser) peek() rune {
+	if p.i < len(p.s) {
+		return p.s[p.i]
+	}
+	return 0
+}
+
+func (p *exprParser) expr() (*big.Rat, error) {
+	r, err := p.term()
+	for err == nil && (p.peek() == '+' || p.peek() == '-') {
+		op := p.peek()
+		p.i++
+		var t *big.Rat
+		if t, err = p.term(); err == nil {
+			if op == '+' {
+				r.Add(r, t)
+			} else {
+				r.Sub(r, t)
+			}
+		}
+	}
+	return r, err
+}
+
+func (p *exprParser) term() (*big.Rat, error) {
+	r, err := p.unary()
+	for err == nil && (p.peek() == '*' || p.peek() == '/') {
+		op := p.peek()
+		p.i++
+		var t *big.Rat
+		if t, err = p.unary(); err == nil {
+			if op == '*' {
+				r.Mul(r, t)
+			} else if t.Sign() == 0 {
+				err = errors.New(lang.L("Division by zero"))
+			} else {
+				r.Quo(r, t)
+			}
+		}
+	}
+	return r, err
+}
+
+func (p *exprParser) unary() (*big.Rat, error) {
+	switch p.peek() {
+	case '+':
+		p.i++
+		return p.unary()
+	case '-':
+		p.i++
+		r, err := p.unary()
+		if err == nil {
+			r.Neg(r)
+		}
+		return r, err
+	}
+
+	r, err := p.primary()
+	for err == nil && p.peek() == '%' {
+		p.i++
+		r.Quo(r, big.NewRat(100, 1))
+	}
+	return r, err
+}
+
+func (p *exprParser) primary() (*big.Rat, error) {
+	if p.peek() == '(' {
+		p.i++
+		r, err := p.expr()
+		if err == nil && p.peek() != ')' {
+			err = errInvalidExpr()
+		}
+		p.i++
+		return r, err
+	}
+
+	var b strings.Builder
+	for ; p.i < len(p.s); p.i++ {
+		c := p.s[p.i]
+		if c == p.dec || c == '.' {
+			c = '.'
+		} else if c < '0' || c > '9' {
+			break
+		}
+		b.WriteRune(c)
+	}
+	r, ok := new(big.Rat).SetString(b.String())
+	if !ok || strings.Count(b.String(), ".") > 1 {
+		return nil, errInvalidExpr()
+	}
+	return r, nil
+}
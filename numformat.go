@@ -0,0 +1,141 @@
+package wx
+
+import (
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/lang"
+)
+
+// NumberFormat describes how a NumEntry reads and displays numbers.
+//
+// Grouping, Prefix and Suffix are only displayed when the entry is not focused.
+type NumberFormat struct {
+	Decimal  rune   // decimal separator (',' if 0)
+	Grouping rune   // thousands separator (no grouping if 0)
+	Prefix   string // e.g. "$"
+	Suffix   string // e.g. " €"
+	Percent  bool   // float values are displayed and typed multiplied by 100 (with a " %" suffix if Suffix is empty)
+}
+
+// DefaultNumberFormat is the format of the NumEntry without Format: the decimal separator of the user's language,
+// without grouping.
+//
+// Applications can use the grouping of the language, or a fixed format:
+//
+//	wx.DefaultNumberFormat = wx.LocaleNumberFormat(lang.SystemLocale())
+//	wx.DefaultNumberFormat = wx.NumberFormat{Decimal: ','}
+var DefaultNumberFormat = NumberFormat{Decimal: LocaleNumberFormat(lang.SystemLocale()).Decimal}
+
+// LocaleNumberFormat returns the usual decimal and grouping separators of a locale (e.g. "fr-FR" or "en").
+func LocaleNumberFormat(l fyne.Locale) NumberFormat {
+	language, region, _ := strings.Cut(strings.ReplaceAll(string(l), "_", "-"), "-")
+	switch {
+	case region == "CH" || region == "LI":
+		return NumberFormat{Decimal: '.', Grouping: '\''}
+	case strings.Contains(" en ja zh ko he th hi ms ", " "+language+" "):
+		return NumberFormat{Decimal: '.', Grouping: ','}
+	case strings.Contains(" de nl it es pt id tr da el ro hr sl ", " "+language+" "):
+		return NumberFormat{Decimal: ',', Grouping: '.'}
+	}
+	return NumberFormat{Decimal: ',', Grouping: '\u00a0'} // no-break space: fr, ru, pl, cs, sv, fi, nb, uk...
+}
+
+// ----------------------------------------------------------------------------
+// internals
+
+func (f NumberFormat) decimal() rune {
+	if f.Decimal == 0 {
+		return ','
+	}
+	return f.Decimal
+}
+
+func (f NumberFormat) suffix() string {
+	if f.Suffix == "" && f.Percent {
+		return " %"
+	}
+	return f.Suffix
+}
+
+// decorated returns wether the displayed text differs from the typed text.
+func (f NumberFormat) decorated() bool {
+	return f.Grouping != 0 || f.Prefix != "" || f.suffix() != ""
+}
+
+// normalize replaces the separators of s (pasted text, or text of another locale) by the decimal separator
+// of the format, and removes the grouping separators.
+//
+// If both '.' and ',' are used, the last one is the decimal separator. If only one of them is used, once,
+// it is the decimal separator unless it is the grouping separator of the format followed by 3 digits.
+func (f NumberFormat) normalize(s string) string {
+	s = strings.TrimSuffix(strings.TrimPrefix(s, f.Prefix), f.suffix())
+
+	var dec rune
+	i := strings.LastIndexAny(s, ".,")
+	dots, commas := strings.Count(s, "."), strings.Count(s, ",")
+	switch {
+	case dots > 0 && commas > 0:
+		dec = rune(s[i])
+	case dots+commas == 1:
+		dec = rune(s[i])
+		if dec != f.decimal() && dec == f.Grouping && threeDigits(s[i+1:]) {
+			dec = 0
+		}
+	}
+
+	var b strings.Builder
+	for j, r := range s {
+		switch {
+		case r == '.' || r == ',':
+			if j == i && r == dec {
+				b.WriteRune(f.decimal())
+			}
+		case r == f.Grouping:
+		default:
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// display returns the displayed text of a typed text (grouping, prefix and suffix).
+func (f NumberFormat) display(s string) string {
+	if s == "" || !f.decorated() {
+		return s
+	}
+
+	sign := ""
+	if s[0] == '+' || s[0] == '-' {
+		sign, s = s[:1], s[1:]
+	}
+	intPart, frac, hasDec := strings.Cut(s, string(f.decimal()))
+
+	var b strings.Builder
+	b.WriteString(f.Prefix)
+	b.WriteString(sign)
+	for i, r := range intPart {
+		if i > 0 && f.Grouping != 0 && (len(intPart)-i)%3 == 0 {
+			b.WriteRune(f.Grouping)
+		}
+		b.WriteRune(r)
+	}
+	if hasDec {
+		b.WriteRune(f.decimal())
+		b.WriteString(frac)
+	}
+	b.WriteString(f.suffix())
+	return b.String()
+}
+
+func threeDigits(s string) bool {
+	if len(s) < 3 || (len(s) > 3 && s[3] >= '0' && s[3] <= '9') {
+		return false
+	}
+	for i := 0; i < 3; i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return false
+		}
+	}
+	return true
+}
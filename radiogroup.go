@@ -0,0 +1,55 @@
+package wx
+
+import (
+	"fyne.io/fyne/v2/widget"
+)
+
+// RadioGroup is a widget.RadioGroup that can be set read-only.
+type RadioGroup struct {
+	widget.RadioGroup
+
+	OnChanged func(string)
+
+	readOnly bool
+	selected string // last selection set by code or accepted from the user (see SetReadOnly)
+}
+
+func NewRadioGroup(options []string, changed func(string)) *RadioGroup {
+	r := &RadioGroup{OnChanged: changed}
+	r.Options = options
+	r.RadioGroup.OnChanged = r.onChanged
+	r.ExtendBaseWidget(r)
+	return r
+}
+
+// ReadOnly returns read-only status.
+//
+// Read-Only widget will display like a normal widget, but it will be impossible to change its selection.
+func (r *RadioGroup) ReadOnly() bool { return r.readOnly }
+
+// SetReadOnly sets read-only status.
+//
+// Read-Only widget will display like a normal widget, but it will be impossible to change its selection.
+// The selection can still be changed by code (see SetSelected).
+func (r *RadioGroup) SetReadOnly(b bool) {
+	r.readOnly = b
+	r.selected = r.Selected
+}
+
+// SetSelected sets the selected option, even if the group is read-only.
+func (r *RadioGroup) SetSelected(option string) {
+	r.selected = option
+	r.RadioGroup.SetSelected(option)
+}
+
+func (r *RadioGroup) onChanged(s string) {
+	if r.readOnly && s != r.selected {
+		// tapped by the user: widget.RadioGroup refreshes the items from Selected after this callback
+		r.Selected = r.selected
+		return
+	}
+	r.selected = s
+	if r.OnChanged != nil {
+		r.OnChanged(s)
+	}
+}
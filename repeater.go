@@ -0,0 +1,284 @@
+package wx
+
+import (
+	"errors"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/lang"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+)
+
+// Repeater is a list of sub-forms (one-to-many rows, e.g. phone numbers or invoice lines),
+// with add, remove and reorder buttons.
+//
+// Each row is an InputFields filled by the template function.
+type Repeater struct {
+	widget.DisableableWidget
+
+	OnChanged func()
+
+	// forwarded from the rows
+	OnTypedKey      func(*fyne.KeyEvent) (block bool)
+	OnTypedShortcut func(fyne.Shortcut) (block bool)
+
+	win      fyne.Window
+	template func(sub *InputFields)
+
+	rows     []*repeaterRow
+	box      *fyne.Container
+	addBtn   *widget.Button
+	readOnly bool
+	updating bool
+
+	err                 error
+	onValidationChanged func(error)
+}
+
+type repeaterRow struct {
+	sub           *InputFields
+	obj           fyne.CanvasObject
+	up, down, del *widget.Button
+	buttons       *fyne.Container
+	disabled      bool
+}
+
+func NewRepeater(win fyne.Window, template func(sub *InputFields)) *Repeater {
+	r := &Repeater{win: win, template: template, box: container.NewVBox()}
+	r.addBtn = widget.NewButtonWithIcon(lang.L("Add"), theme.ContentAddIcon(), func() { r.AddRow() })
+	r.ExtendBaseWidget(r)
+	return r
+}
+
+func (r *Repeater) CreateRenderer() fyne.WidgetRenderer {
+	return widget.NewSimpleRenderer(container.NewVBox(r.box, container.NewHBox(r.addBtn)))
+}
+
+// ----------------------------------------------------------------------------
+// Rows
+
+// Len returns the number of rows.
+func (r *Repeater) Len() int { return len(r.rows) }
+
+// Row returns the sub-form of a row (nil if out of range).
+func (r *Repeater) Row(i int) *InputFields {
+	if i < 0 || i >= len(r.rows) {
+		return nil
+	}
+	return r.rows[i].sub
+}
+
+// AddRow appends a new row, created by the template, and returns its sub-form.
+func (r *Repeater) AddRow() *InputFields {
+	row := &repeaterRow{sub: NewInputFields(r.win)}
+	if r.template != nil {
+		r.template(row.sub)
+	}
+	row.sub.ClearHistory()
+	row.sub.OnChanged = func(_ FieldID) { r.changed() }
+	row.sub.OnTypedKey = func(ke *fyne.KeyEvent) bool { return r.OnTypedKey != nil && r.OnTypedKey(ke) }
+	row.sub.OnTypedShortcut = func(s fyne.Shortcut) bool { return r.OnTypedShortcut != nil && r.OnTypedShortcut(s) }
+
+	row.up = widget.NewButtonWithIcon("", theme.MoveUpIcon(), func() { r.moveRow(row, -1) })
+	row.down = widget.NewButtonWithIcon("", theme.MoveDownIcon(), func() { r.moveRow(row, 1) })
+	row.del = widget.NewButtonWithIcon("", theme.DeleteIcon(), func() { r.RemoveRow(r.indexRow(row)) })
+	row.up.Importance, row.down.Importance, row.del.Importance = widget.LowImportance, widget.LowImportance, widget.LowImportance
+	row.buttons = container.NewHBox(row.up, row.down, row.del)
+	row.obj = container.NewBorder(nil, nil, nil, container.NewVBox(row.buttons), row.sub)
+
+	r.rows = append(r.rows, row)
+	r.update()
+	r.changed()
+	return row.sub
+}
+
+// RemoveRow removes a row.
+func (r *Repeater) RemoveRow(i int) {
+	if i < 0 || i >= len(r.rows) {
+		return
+	}
+	r.rows = append(r.rows[:i], r.rows[i+1:]...)
+	r.update()
+	r.changed()
+}
+
+// MoveRow moves the row i to the index j.
+func (r *Repeater) MoveRow(i, j int) {
+	if i < 0 || i >= len(r.rows) || j < 0 || j >= len(r.rows) || i == j {
+		return
+	}
+	row := r.rows[i]
+	r.rows = append(r.rows[:i], r.rows[i+1:]...)
+	r.rows = append(r.rows[:j], append([]*repeaterRow{row}, r.rows[j:]...)...)
+	r.update()
+	r.changed()
+}
+
+// ----------------------------------------------------------------------------
+// Values
+
+// GetValues returns the values of the rows (see InputFields.ReadAll).
+func (r *Repeater) GetValues() []map[string]any {
+	ret := make([]map[string]any, len(r.rows))
+	for i, row := range r.rows {
+		ret[i] = row.sub.ReadAll()
+	}
+	return ret
+}
+
+// SetValues sets the rows (adding or removing rows as needed), and calls OnChanged.
+//
+// Null values (nil) set nullable inputs to null.
+func (r *Repeater) SetValues(values []map[string]any) {
+	r.updating = true
+	for len(r.rows) > len(values) {
+		r.RemoveRow(len(r.rows) - 1)
+	}
+	for len(r.rows) < len(values) {
+		r.AddRow()
+	}
+	for i, row := range r.rows {
+		for id, v := range values[i] {
+			row.sub.setValue(id, v)
+		}
+		row.sub.ClearHistory()
+	}
+	r.updating = false
+	r.changed()
+}
+
+// ----------------------------------------------------------------------------
+// Validatable
+
+// Validate returns an error if a row is invalid (the errors are displayed in the rows as they are edited).
+func (r *Repeater) Validate() error {
+	for i, row := range r.rows {
+		if !row.sub.Valid() {
+			return errors.New(lang.L("Row {{.N}} is invalid", map[string]any{"N": i + 1}))
+		}
+	}
+	return nil
+}
+
+// SetOnValidationChanged is used to set the callback that will be triggered when the validation state changes.
+func (r *Repeater) SetOnValidationChanged(callback func(error)) {
+	r.onValidationChanged = callback
+}
+
+// validateRows displays the errors of all the rows (see InputFields.Validate).
+func (r *Repeater) validateRows() {
+	for _, row := range r.rows {
+		row.sub.Validate()
+	}
+}
+
+// ----------------------------------------------------------------------------
+// Disableable / ReadOnlyable
+
+func (r *Repeater) Enable() {
+	r.DisableableWidget.Enable()
+	r.update()
+}
+
+func (r *Repeater) Disable() {
+	r.DisableableWidget.Disable()
+	r.update()
+}
+
+// ReadOnly returns read-only status.
+//
+// Read-Only widget will display like a normal widget, but it will be impossible to modify the rows.
+func (r *Repeater) ReadOnly() bool { return r.readOnly }
+
+// SetReadOnly sets read-only status.
+//
+// Read-Only widget will display like a normal widget, but it will be impossible to modify the rows.
+func (r *Repeater) SetReadOnly(b bool) {
+	r.readOnly = b
+	r.update()
+}
+
+// ----------------------------------------------------------------------------
+// internals
+
+func (r *Repeater) changed() {
+	if r.updating {
+		return
+	}
+	if err := r.Validate(); (err == nil) != (r.err == nil) {
+		r.err = err
+		if r.onValidationChanged != nil {
+			r.onValidationChanged(err)
+		}
+	}
+	if r.OnChanged != nil {
+		r.OnChanged()
+	}
+}
+
+func (r *Repeater) indexRow(row *repeaterRow) int {
+	for i := range r.rows {
+		if r.rows[i] == row {
+			return i
+		}
+	}
+	return -1
+}
+
+func (r *Repeater) moveRow(row *repeaterRow, delta int) {
+	if i := r.indexRow(row); i >= 0 {
+		r.MoveRow(i, i+delta)
+	}
+}
+
+// update rebuilds the rows list and updates the state of the rows and buttons.
+func (r *Repeater) update() {
+	disabled := r.Disabled()
+
+	objects := make([]fyne.CanvasObject, 0, 2*len(r.rows))
+	for i, row := range r.rows {
+		if i > 0 {
+			objects = append(objects, widget.NewSeparator())
+		}
+		objects = append(objects, row.obj)
+
+		if disabled != row.disabled {
+			row.disabled = disabled
+			if disabled {
+				row.sub.Disable()
+			} else {
+				row.sub.Enable()
+			}
+		}
+		if row.sub.ReadOnly() != r.readOnly {
+			row.sub.SetReadOnly(r.readOnly)
+		}
+
+		setEnabled(row.up, !disabled && i > 0)
+		setEnabled(row.down, !disabled && i < len(r.rows)-1)
+		setEnabled(row.del, !disabled)
+		if r.readOnly {
+			row.buttons.Hide()
+		} else {
+			row.buttons.Show()
+		}
+	}
+	r.box.Objects = objects
+	r.box.Refresh()
+
+	setEnabled(r.addBtn, !disabled)
+	if r.readOnly {
+		r.addBtn.Hide()
+	} else {
+		r.addBtn.Show()
+	}
+}
+
+func setEnabled(w fyne.Disableable, b bool) {
+	if b {
+		w.Enable()
+	} else {
+		w.Disable()
+	}
+}
@@ -2,6 +2,7 @@ package wx
 
 import (
 	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/data/binding"
 	"fyne.io/fyne/v2/driver/desktop"
 	"fyne.io/fyne/v2/widget"
 )
@@ -12,11 +13,16 @@ type Select struct {
 	ToolTipable
 
 	// custom callbacks
+	OnChanged       func(string)
 	OnFocusGained   func()
 	OnFocusLost     func()
 	OnTypedRune     func(rune) (block bool)
 	OnTypedKey      func(*fyne.KeyEvent) (block bool)
 	OnTypedShortcut func(fyne.Shortcut) (block bool)
+
+	readOnly bool
+
+	binder dataBinder
 }
 
 func NewSelect(options []string, onChanged func(s string)) *Select {
@@ -25,9 +31,50 @@ func NewSelect(options []string, onChanged func(s string)) *Select {
 	sel.ExtendBaseWidget(sel)
 	sel.Options = options
 	sel.OnChanged = onChanged
+	sel.Select.OnChanged = sel.onChanged
 	return sel
 }
 
+// Bind connects the select to a string data source.
+// Changes of the data update the selection, and selecting an option writes to the data.
+//
+// Unlike widget.Select.Bind, OnChanged is kept.
+func (sel *Select) Bind(data binding.String) {
+	sel.binder.bind(data, func() {
+		if s, err := data.Get(); err == nil && s != sel.Selected {
+			if s == "" {
+				sel.ClearSelected()
+			} else {
+				sel.SetSelected(s)
+			}
+		}
+	})
+}
+
+// Unbind disconnects the data source set by Bind.
+// The select keeps its current selection.
+func (sel *Select) Unbind() {
+	sel.binder.unbind()
+}
+
+// ReadOnly returns read-only status.
+//
+// Read-Only widget will display like a normal widget, but it will be impossible to change its selection.
+func (sel *Select) ReadOnly() bool { return sel.readOnly }
+
+// SetReadOnly sets read-only status.
+//
+// Read-Only widget will display like a normal widget, but it will be impossible to change its selection.
+func (sel *Select) SetReadOnly(b bool) { sel.readOnly = b }
+
+// Tapped is called when a pointer tapped event is captured and triggers any tap handler
+func (sel *Select) Tapped(pe *fyne.PointEvent) {
+	if sel.readOnly {
+		return
+	}
+	sel.Select.Tapped(pe)
+}
+
 // FocusGained is a hook called by the focus handling logic after this object gained the focus.
 func (sel *Select) FocusGained() {
 	sel.Select.FocusGained()
@@ -46,6 +93,9 @@ func (sel *Select) FocusLost() {
 
 // TypedRune is a hook called by the input handling logic on text input events if this object is focused.
 func (sel *Select) TypedRune(r rune) {
+	if sel.readOnly {
+		return
+	}
 	if sel.OnTypedRune != nil && sel.OnTypedRune(r) {
 		return
 	}
@@ -54,6 +104,9 @@ func (sel *Select) TypedRune(r rune) {
 
 // TypedKey is a hook called by the input handling logic on key events if this object is focused.
 func (sel *Select) TypedKey(e *fyne.KeyEvent) {
+	if sel.readOnly {
+		return
+	}
 	if sel.OnTypedKey != nil && sel.OnTypedKey(e) {
 		return
 	}
@@ -67,6 +120,13 @@ func (sel *Select) TypedShortcut(s fyne.Shortcut) {
 	// sel.Select.TypedShortcut(s) // doesn't exists
 }
 
+func (sel *Select) onChanged(s string) {
+	if sel.OnChanged != nil {
+		sel.OnChanged(s)
+	}
+	sel.binder.toData(func() { sel.binder.data.(binding.String).Set(s) })
+}
+
 // MouseIn is a hook that is called if the mouse pointer enters the element.
 func (sel *Select) MouseIn(me *desktop.MouseEvent) {
 	sel.ToolTipable.MouseIn(me)
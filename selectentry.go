@@ -20,6 +20,7 @@ type SelectEntry struct {
 
 	readOnly bool
 	minCols  int
+	options  []string
 }
 
 func NewSelectEntry(options []string) *SelectEntry {
@@ -30,6 +31,15 @@ func NewSelectEntry(options []string) *SelectEntry {
 	return sel
 }
 
+// SetOptions sets the options the user might select from.
+func (sel *SelectEntry) SetOptions(options []string) {
+	sel.options = options
+	sel.SelectEntry.SetOptions(options)
+}
+
+// Options returns the options the user might select from.
+func (sel *SelectEntry) Options() []string { return sel.options }
+
 func (sel *SelectEntry) ReadOnly() bool { return sel.readOnly }
 func (sel *SelectEntry) SetReadOnly(b bool) {
 	sel.readOnly = b
@@ -0,0 +1,403 @@
+package wx
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/driver/desktop"
+	"fyne.io/fyne/v2/lang"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+)
+
+// TimeFormat describes how a TimeEntry reads and displays times of day.
+type TimeFormat struct {
+	Seconds bool // HH:MM:SS instead of HH:MM
+	Hour12  bool // 12-hour clock with an AM/PM suffix (typed with the A and P keys)
+}
+
+// DefaultTimeFormat is the format of the TimeEntry without Format.
+var DefaultTimeFormat = TimeFormat{}
+
+// Layout returns the time layout of the format (e.g. "15:04"), see time.Format.
+func (f TimeFormat) Layout() string {
+	hour := "15"
+	if f.Hour12 {
+		hour = "03"
+	}
+	return f.join(map[byte]string{'h': hour, 'm': "04", 's': "05", 'p': "PM"})
+}
+
+// TimeEntry is a masked input of a time of day (see TimeFormat).
+//
+// Its value is a time.Time of the day 0000-01-01 (see time.Parse), the zero time if the entry is empty.
+type TimeEntry struct {
+	widget.Entry
+
+	Format *TimeFormat // seconds and 12-hour clock (DefaultTimeFormat if nil, see SetFormat)
+
+	lastValidTime time.Time
+
+	ToolTipable
+
+	// custom callbacks
+	OnChanged       func(time.Time)
+	OnFocusGained   func()
+	OnFocusLost     func()
+	OnTypedRune     func(r rune) (block bool)
+	OnTypedKey      func(k *fyne.KeyEvent) (block bool)
+	OnTypedShortcut func(s fyne.Shortcut) (block bool)
+
+	readOnly bool
+
+	invalid             bool
+	onValidationChanged func(error)
+}
+
+func NewTimeEntry() *TimeEntry {
+	t := &TimeEntry{}
+	t.ToolTipable.parent = t
+	t.ExtendBaseWidget(t)
+
+	t.Text = t.format().mask()
+	t.Entry.OnChanged = func(s string) {
+		if invalid := t.Text != t.format().mask() && t.GetText() == ""; invalid != t.invalid {
+			t.invalid = invalid
+			if t.onValidationChanged != nil {
+				t.onValidationChanged(t.Validate())
+			}
+		}
+
+		tm := t.GetTime()
+		if !tm.Equal(t.lastValidTime) {
+			if t.OnChanged != nil {
+				t.OnChanged(tm)
+			}
+			t.lastValidTime = tm
+		}
+	}
+	return t
+}
+
+// SetFormat changes the time format (nil for DefaultTimeFormat), the time is kept.
+func (t *TimeEntry) SetFormat(f *TimeFormat) {
+	tm := t.GetTime()
+	t.Format = f
+	t.Text = t.format().mask()
+	t.CursorColumn = 0
+	if !tm.IsZero() {
+		t.Text = tm.Format(t.format().Layout())
+		t.CursorColumn = len(t.Text)
+	}
+	t.Refresh()
+}
+
+// SetText sets the time from its digits (separators are ignored), and the A or P of 12-hour clocks.
+func (t *TimeEntry) SetText(s string) {
+	t.Text = t.format().mask()
+	t.CursorColumn = 0
+	for _, r := range s {
+		t.TypedRune(r)
+	}
+	t.Refresh()
+	t.callOnChanged()
+}
+
+func (t *TimeEntry) GetText() string {
+	if _, err := time.Parse(t.format().Layout(), t.Text); err != nil {
+		return ""
+	}
+	return t.Text
+}
+
+// SetTime sets the time of day of tm (the zero time clears the entry).
+func (t *TimeEntry) SetTime(tm time.Time) {
+	if tm.IsZero() {
+		t.Text = t.format().mask()
+		t.CursorColumn = 0
+	} else {
+		t.Text = tm.Format(t.format().Layout())
+		t.CursorColumn = len(t.Text)
+	}
+	t.Refresh()
+	t.callOnChanged()
+}
+
+// GetTime returns the time of day, on the day 0000-01-01 UTC (zero time if empty or invalid).
+func (t *TimeEntry) GetTime() time.Time {
+	tm, _ := time.Parse(t.format().Layout(), t.Text)
+	return tm
+}
+
+// Validate returns an error if the time is partially typed or invalid (e.g. 25:00),
+// then calls the Entry Validator if any.
+func (t *TimeEntry) Validate() error {
+	if t.Text != t.format().mask() && t.GetText() == "" {
+		return errors.New(lang.L("Invalid time"))
+	}
+	return t.Entry.Validate()
+}
+
+// SetOnValidationChanged is intended for parent widgets or containers to hook into the validation.
+func (t *TimeEntry) SetOnValidationChanged(callback func(error)) {
+	t.onValidationChanged = callback
+	t.Entry.SetOnValidationChanged(callback)
+}
+
+func (t *TimeEntry) ReadOnly() bool {
+	return t.readOnly
+}
+func (t *TimeEntry) SetReadOnly(b bool) {
+	t.readOnly = b
+	if cnv := fyne.CurrentApp().Driver().CanvasForObject(t); b && cnv != nil && cnv.Focused() == t {
+		cnv.Focus(nil)
+	}
+	t.Refresh()
+}
+
+func (t *TimeEntry) MinSize() fyne.Size {
+	s := t.Entry.MinSize()
+	s.Width = fyne.MeasureText(strings.ReplaceAll(t.format().mask(), "_", "0"), theme.TextSize(), t.TextStyle).Width + 2*theme.InnerPadding() + 2*theme.InputBorderSize()
+	return s
+}
+
+func (t *TimeEntry) FocusGained() {
+	if t.readOnly {
+		return
+	}
+	t.Entry.FocusGained()
+	if t.OnFocusGained != nil {
+		t.OnFocusGained()
+	}
+}
+
+func (t *TimeEntry) FocusLost() {
+	t.Entry.FocusLost()
+	if t.OnFocusLost != nil {
+		t.OnFocusLost()
+	}
+}
+
+func (t *TimeEntry) TypedRune(r rune) {
+	if t.readOnly || t.OnTypedRune != nil && t.OnTypedRune(r) {
+		return
+	}
+
+	f := t.format()
+	switch r {
+	case '0', '1', '2', '3', '4', '5', '6', '7', '8', '9':
+		// the cursor skips the separators, e.g. __:__ 0, 1, :2, 3, 4 [, 5]
+		runes := []rune(t.Text)
+		if f.isSeparator(t.CursorColumn) {
+			t.CursorColumn += 1
+		}
+		if t.CursorColumn < len(runes) && f.fieldAt(t.CursorColumn) != 'p' {
+			runes[t.CursorColumn] = r
+			t.CursorColumn += 1
+			if f.isSeparator(t.CursorColumn) {
+				t.CursorColumn += 1
+			}
+			t.Text = string(runes)
+			t.Refresh()
+			t.callOnChanged()
+		}
+	case 'a', 'A', 'p', 'P':
+		if f.Hour12 {
+			t.setPeriod(strings.ToUpper(string(r)) + "M")
+			t.Refresh()
+			t.callOnChanged()
+		}
+	}
+}
+
+func (t *TimeEntry) TypedKey(k *fyne.KeyEvent) {
+	if t.OnTypedKey != nil && t.OnTypedKey(k) {
+		return
+	}
+	if t.readOnly && k.Name != fyne.KeyLeft && k.Name != fyne.KeyRight {
+		return
+	}
+
+	f := t.format()
+	switch k.Name {
+	case fyne.KeyRight:
+		t.CursorColumn += 1
+		if n := len([]rune(t.Text)); t.CursorColumn >= n {
+			t.CursorColumn = n
+		}
+		if f.isSeparator(t.CursorColumn) {
+			t.CursorColumn += 1
+		}
+	case fyne.KeyLeft:
+		t.CursorColumn -= 1
+		if t.CursorColumn <= 0 {
+			t.CursorColumn = 0
+		}
+		if f.isSeparator(t.CursorColumn) {
+			t.CursorColumn -= 1
+		}
+	case fyne.KeyUp, fyne.KeyDown:
+		delta := 1
+		if k.Name == fyne.KeyDown {
+			delta = -1
+		}
+		switch k := f.fieldAt(t.CursorColumn); k {
+		case 'h':
+			min, max := 0, 23
+			if f.Hour12 {
+				min, max = 1, 12
+			}
+			t.setField(k, wrapInt(t.getField(k)+delta, min, max))
+		case 'm', 's':
+			t.setField(k, wrapInt(t.getField(k)+delta, 0, 59))
+		case 'p':
+			if start, _ := f.field('p'); string([]rune(t.Text)[start:]) == "AM" {
+				t.setPeriod("PM")
+			} else {
+				t.setPeriod("AM")
+			}
+		}
+		t.callOnChanged()
+	case fyne.KeyBackspace:
+		runes := []rune(t.Text)
+		if t.CursorColumn > 0 && f.isSeparator(t.CursorColumn-1) {
+			t.CursorColumn -= 1
+		} else if t.CursorColumn > 0 {
+			runes[t.CursorColumn-1] = '_'
+			t.CursorColumn -= 1
+			if f.isSeparator(t.CursorColumn - 1) {
+				t.CursorColumn -= 1
+			}
+		}
+		t.Text = string(runes)
+		t.callOnChanged()
+	case fyne.KeyDelete, fyne.KeyEscape:
+		t.Text = f.mask()
+		t.CursorColumn = 0
+		t.callOnChanged()
+	default:
+		return
+	}
+	t.Refresh()
+}
+
+func (t *TimeEntry) TypedShortcut(shortcut fyne.Shortcut) {
+	if t.OnTypedShortcut != nil && t.OnTypedShortcut(shortcut) {
+		return
+	}
+
+	if s, ok := shortcut.(*fyne.ShortcutPaste); ok {
+		for _, r := range s.Clipboard.Content() {
+			t.TypedRune(r)
+		}
+		t.callOnChanged()
+	} else {
+		t.Entry.TypedShortcut(shortcut)
+	}
+}
+
+func (t *TimeEntry) MouseIn(me *desktop.MouseEvent)    { t.ToolTipable.MouseIn(me) }
+func (t *TimeEntry) MouseMoved(me *desktop.MouseEvent) { t.ToolTipable.MouseMoved(me) }
+func (t *TimeEntry) MouseOut()                         { t.ToolTipable.MouseOut() }
+
+//
+
+func (t *TimeEntry) format() TimeFormat {
+	if t.Format != nil {
+		return *t.Format
+	}
+	return DefaultTimeFormat
+}
+
+func (t *TimeEntry) callOnChanged() {
+	t.Entry.OnChanged(t.Text)
+}
+
+// setField replaces the digits of a field ('h', 'm' or 's').
+func (t *TimeEntry) setField(k byte, v int) {
+	start, n := t.format().field(k)
+	runes := []rune(t.Text)
+	copy(runes[start:start+n], []rune(fmt.Sprintf("%0*d", n, v)))
+	t.Text = string(runes)
+}
+func (t *TimeEntry) getField(k byte) int {
+	start, n := t.format().field(k)
+	ret, err := strconv.Atoi(string([]rune(t.Text)[start : start+n]))
+	if err != nil {
+		return 0
+	}
+	return ret
+}
+
+func (t *TimeEntry) setPeriod(p string) {
+	start, n := t.format().field('p')
+	runes := []rune(t.Text)
+	copy(runes[start:start+n], []rune(p))
+	t.Text = string(runes)
+}
+
+func wrapInt(v, min, max int) int {
+	if v > max {
+		return min
+	}
+	if v < min {
+		return max
+	}
+	return v
+}
+
+// format internals (see DateFormat)
+
+// fields returns the fields in display order ('h', 'm', 's', 'p' for AM/PM).
+func (f TimeFormat) fields() string {
+	s := "hm"
+	if f.Seconds {
+		s += "s"
+	}
+	if f.Hour12 {
+		s += "p"
+	}
+	return s
+}
+
+func (f TimeFormat) join(parts map[byte]string) string {
+	var b strings.Builder
+	for i, k := range []byte(f.fields()) {
+		if k == 'p' {
+			b.WriteRune(' ')
+		} else if i > 0 {
+			b.WriteRune(':')
+		}
+		b.WriteString(parts[k])
+	}
+	return b.String()
+}
+
+// mask returns the text of an empty time (e.g. "__:__").
+func (f TimeFormat) mask() string {
+	return f.join(map[byte]string{'h': "__", 'm': "__", 's': "__", 'p': "__"})
+}
+
+// field returns the position (in runes) of a field in the text, all fields are 2 runes long.
+func (f TimeFormat) field(k byte) (start, n int) {
+	return 3 * strings.IndexByte(f.fields(), k), 2
+}
+
+// fieldAt returns the field at a cursor position (the cursor can be just after the field).
+func (f TimeFormat) fieldAt(col int) byte {
+	fields := f.fields()
+	if i := col / 3; i < len(fields) {
+		return fields[i]
+	}
+	return fields[len(fields)-1]
+}
+
+// isSeparator returns wether the rune at a cursor position is a separator.
+func (f TimeFormat) isSeparator(col int) bool {
+	mask := []rune(f.mask())
+	return col >= 0 && col < len(mask) && mask[col] != '_'
+}
@@ -0,0 +1,19 @@
+package wx
+
+import (
+	"embed"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/lang"
+)
+
+// translations of the texts of the widgets (see lang.L), applications can override them with their own
+//
+//go:embed translations
+var translations embed.FS
+
+func init() {
+	if err := lang.AddTranslationsFS(translations, "translations"); err != nil {
+		fyne.LogError("wx: invalid translations", err)
+	}
+}
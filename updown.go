@@ -1,98 +1,201 @@
 package wx
 
 import (
+	"time"
+
 	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/driver/desktop"
+	"fyne.io/fyne/v2/theme"
 	"fyne.io/fyne/v2/widget"
 )
 
+const (
+	upDownRepeatDelay    = 400 * time.Millisecond // before auto-repeat starts
+	upDownRepeatInterval = 80 * time.Millisecond
+)
+
+// UpDownButton is a compact spinner: two arrows stacked vertically (e.g. as the ActionItem of an entry,
+// see NumEntry.Spinner).
+//
+// Keeping an arrow pressed repeats OnUp/OnDown.
 type UpDownButton struct {
 	widget.DisableableWidget
-}
-
-// geometry
-
-// MinSize returns the minimum size this object needs to be drawn.
-func (w *UpDownButton) MinSize() fyne.Size {
-	panic("not implemented") // TODO: Implement
-}
-
-// Move moves this object to the given position relative to its parent.
-// This should only be called if your object is not in a container with a layout manager.
-func (w *UpDownButton) Move(_ fyne.Position) {
-	panic("not implemented") // TODO: Implement
-}
-
-// Position returns the current position of the object relative to its parent.
-func (w *UpDownButton) Position() fyne.Position {
-	panic("not implemented") // TODO: Implement
-}
-
-// Resize resizes this object to the given size.
-// This should only be called if your object is not in a container with a layout manager.
-func (w *UpDownButton) Resize(_ fyne.Size) {
-	panic("not implemented") // TODO: Implement
-}
 
-// Size returns the current size of this object.
-func (w *UpDownButton) Size() fyne.Size {
-	panic("not implemented") // TODO: Implement
-}
-
-// visibility
-
-// Hide hides this object.
-func (w *UpDownButton) Hide() {
-	panic("not implemented") // TODO: Implement
-}
-
-// Visible returns whether this object is visible or not.
-func (w *UpDownButton) Visible() bool {
-	panic("not implemented") // TODO: Implement
-}
-
-// Show shows this object.
-func (w *UpDownButton) Show() {
-	panic("not implemented") // TODO: Implement
-}
-
-// Refresh must be called if this object should be redrawn because its inner state changed.
-func (w *UpDownButton) Refresh() {
-	panic("not implemented") // TODO: Implement
-}
-
-// CreateRenderer returns a new WidgetRenderer for this widget.
-// This should not be called by regular code, it is used internally to render a widget.
-func (w *UpDownButton) CreateRenderer() fyne.WidgetRenderer {
-	panic("not implemented") // TODO: Implement
-}
+	OnUp   func()
+	OnDown func()
+
+	hovered int // 1 up, -1 down
+	pressed bool
+	stop    chan struct{} // auto-repeat
+}
+
+func NewUpDownButton(up, down func()) *UpDownButton {
+	b := &UpDownButton{OnUp: up, OnDown: down}
+	b.ExtendBaseWidget(b)
+	return b
+}
+
+// Tapped is only used when there is no mouse (mobile), MouseDown handles the taps otherwise.
+func (b *UpDownButton) Tapped(pe *fyne.PointEvent) {
+	if b.pressed {
+		b.pressed = false
+		return
+	}
+	b.step(b.arrowAt(pe.Position))
+}
+
+func (b *UpDownButton) MouseDown(me *desktop.MouseEvent) {
+	if me.Button != desktop.MouseButtonPrimary || b.Disabled() {
+		return
+	}
+	b.pressed = true
+	dir := b.arrowAt(me.Position)
+	b.step(dir)
+	b.startRepeat(dir)
+}
+
+func (b *UpDownButton) MouseUp(_ *desktop.MouseEvent) { b.stopRepeat() }
+
+func (b *UpDownButton) MouseIn(me *desktop.MouseEvent) { b.MouseMoved(me) }
+
+func (b *UpDownButton) MouseMoved(me *desktop.MouseEvent) {
+	if dir := b.arrowAt(me.Position); dir != b.hovered {
+		b.hovered = dir
+		b.Refresh()
+	}
+}
+
+func (b *UpDownButton) MouseOut() {
+	b.stopRepeat()
+	b.hovered = 0
+	b.Refresh()
+}
+
+func (b *UpDownButton) CreateRenderer() fyne.WidgetRenderer {
+	r := &upDownRenderer{
+		widget: b,
+		bg:     &canvas.Rectangle{},
+		up:     &canvas.Image{FillMode: canvas.ImageFillContain},
+		down:   &canvas.Image{FillMode: canvas.ImageFillContain},
+	}
+	r.Refresh()
+	return r
+}
+
+// ----------------------------------------------------------------------------
+// internals
+
+func (b *UpDownButton) arrowAt(pos fyne.Position) int {
+	if pos.Y < b.Size().Height/2 {
+		return 1
+	}
+	return -1
+}
+
+func (b *UpDownButton) step(dir int) {
+	if b.Disabled() {
+		return
+	}
+	if dir > 0 && b.OnUp != nil {
+		b.OnUp()
+	} else if dir < 0 && b.OnDown != nil {
+		b.OnDown()
+	}
+}
+
+func (b *UpDownButton) startRepeat(dir int) {
+	b.stopRepeat()
+	stop := make(chan struct{})
+	b.stop = stop
+
+	go func() {
+		delay := time.NewTimer(upDownRepeatDelay)
+		defer delay.Stop()
+		select {
+		case <-stop:
+			return
+		case <-delay.C:
+		}
+
+		tick := time.NewTicker(upDownRepeatInterval)
+		defer tick.Stop()
+		for {
+			select {
+			case <-stop:
+				return
+			case <-tick.C:
+				fyne.Do(func() {
+					if b.stop == stop {
+						b.step(dir)
+					}
+				})
+			}
+		}
+	}()
+}
+
+func (b *UpDownButton) stopRepeat() {
+	if b.stop != nil {
+		close(b.stop)
+		b.stop = nil
+	}
+}
+
+// ----------------------------------------------------------------------------
+// renderer
 
 type upDownRenderer struct {
-	widget *UpDownButton
+	widget   *UpDownButton
+	bg       *canvas.Rectangle // hovered arrow
+	up, down *canvas.Image
 }
 
-// Destroy is for internal use.
 func (r *upDownRenderer) Destroy() {
-	panic("not implemented") // TODO: Implement
+	r.widget.stopRepeat()
 }
 
-// Layout is a hook that is called if the widget needs to be laid out.
-// This should never call Refresh.
-func (r *upDownRenderer) Layout(_ fyne.Size) {
-	panic("not implemented") // TODO: Implement
+func (r *upDownRenderer) Layout(size fyne.Size) {
+	half := fyne.NewSize(size.Width, size.Height/2)
+	r.up.Move(fyne.NewPos(0, 0))
+	r.up.Resize(half)
+	r.down.Move(fyne.NewPos(0, half.Height))
+	r.down.Resize(half)
+
+	r.bg.Resize(half)
+	if r.widget.hovered < 0 {
+		r.bg.Move(fyne.NewPos(0, half.Height))
+	} else {
+		r.bg.Move(fyne.NewPos(0, 0))
+	}
 }
 
-// MinSize returns the minimum size of the widget that is rendered by this renderer.
 func (r *upDownRenderer) MinSize() fyne.Size {
-	panic("not implemented") // TODO: Implement
+	s := theme.IconInlineSize()
+	return fyne.NewSize(s, s)
 }
 
-// Objects returns all objects that should be drawn.
 func (r *upDownRenderer) Objects() []fyne.CanvasObject {
-	panic("not implemented") // TODO: Implement
+	return []fyne.CanvasObject{r.bg, r.up, r.down}
 }
 
-// Refresh is a hook that is called if the widget has updated and needs to be redrawn.
-// This might trigger a Layout.
 func (r *upDownRenderer) Refresh() {
-	panic("not implemented") // TODO: Implement
+	up, down := theme.MenuDropUpIcon(), theme.MenuDropDownIcon()
+	if r.widget.Disabled() {
+		up, down = theme.NewDisabledResource(up), theme.NewDisabledResource(down)
+	}
+	r.up.Resource, r.down.Resource = up, down
+
+	r.bg.FillColor = theme.Color(theme.ColorNameHover)
+	r.bg.CornerRadius = theme.InputRadiusSize()
+	if r.widget.hovered == 0 || r.widget.Disabled() {
+		r.bg.Hide()
+	} else {
+		r.bg.Show()
+	}
+
+	r.Layout(r.widget.Size())
+	r.bg.Refresh()
+	r.up.Refresh()
+	r.down.Refresh()
 }